@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/marvincaspar/clockify2cats/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var flagConfigListKeys bool
+
+func newConfigCmd(file config.File, getenv func(key string) string) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get, set, list and edit the settings of the config file",
+		Long: `Read and change single settings of the config file without running init again.
+Nested keys are separated by dots, e.g. "holidays.state". List values are set as comma-separated string.
+With --profile, the settings of the profile are read and changed, set creates the profile if it does not exist.`,
+	}
+
+	exitOnError := func(err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(configGet(cmd.OutOrStdout(), file, flagProfile, args[0]))
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Validate and store the value of a setting",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(configSet(file, flagProfile, args[0], args[1]))
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a setting, the default is used again",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(configUnset(file, flagProfile, args[0]))
+		},
+	})
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print all settings of the config file, secrets are redacted",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if flagConfigListKeys {
+				writeConfigKeys(cmd.OutOrStdout())
+				return
+			}
+			exitOnError(configList(cmd.OutOrStdout(), file, flagProfile))
+		},
+	}
+	listCmd.Flags().BoolVar(&flagConfigListKeys, "keys", false, "List all known keys with their type instead")
+	configCmd.AddCommand(listCmd)
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the config file",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintln(cmd.OutOrStdout(), file.Path)
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in $VISUAL or $EDITOR and validate it afterwards",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			exitOnError(configEdit(cmd.ErrOrStderr(), file, getenv))
+		},
+	})
+
+	return configCmd
+}
+
+func configGet(w io.Writer, file config.File, profile string, key string) error {
+	c, err := loadProfile(file, profile)
+	if err != nil {
+		return err
+	}
+
+	value, ok := c.Get(key)
+	if !ok {
+		if _, known := config.FindKey(key); !known {
+			return fmt.Errorf("unknown key %q, see config list --keys", key)
+		}
+		return fmt.Errorf("%s is not set", key)
+	}
+	fmt.Fprintln(w, config.Format(value))
+
+	return nil
+}
+
+func configSet(file config.File, profile string, key string, value string) error {
+	c, err := file.Load()
+	if err != nil {
+		return err
+	}
+	settings, _ := c.Profile(profile, true)
+	if err := settings.Set(key, value); err != nil {
+		return err
+	}
+
+	return file.Save(c)
+}
+
+func configUnset(file config.File, profile string, key string) error {
+	c, err := file.Load()
+	if err != nil {
+		return err
+	}
+	settings, ok := c.Profile(profile, false)
+	if !ok {
+		return unknownProfileError(c, profile)
+	}
+	if !settings.Unset(key) {
+		return fmt.Errorf("%s is not set", key)
+	}
+
+	return file.Save(c)
+}
+
+// configList prints every setting as "key = value", keys that are not read by clockify2cats are marked.
+// Without profile, the settings of all profiles are listed as well.
+func configList(w io.Writer, file config.File, profile string) error {
+	c, err := loadProfile(file, profile)
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range c.Settings() {
+		if setting.Known {
+			fmt.Fprintf(w, "%s = %s\n", setting.Key, setting.Value)
+		} else {
+			fmt.Fprintf(w, "%s = %s  # unknown key\n", setting.Key, setting.Value)
+		}
+	}
+
+	return nil
+}
+
+// loadProfile returns the settings of the profile, not the settings resolved with the top level.
+func loadProfile(file config.File, profile string) (config.Config, error) {
+	c, err := file.Load()
+	if err != nil {
+		return nil, err
+	}
+	settings, ok := c.Profile(profile, false)
+	if !ok {
+		return nil, unknownProfileError(c, profile)
+	}
+
+	return settings, nil
+}
+
+func unknownProfileError(c config.Config, profile string) error {
+	if len(c.Profiles()) == 0 {
+		return fmt.Errorf("unknown profile %q, the config file has no profiles", profile)
+	}
+
+	return fmt.Errorf("unknown profile %q, must be one of %s", profile, strings.Join(c.Profiles(), ", "))
+}
+
+func writeConfigKeys(w io.Writer) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "KEY\tTYPE")
+	for _, key := range config.Keys {
+		fmt.Fprintf(table, "%s\t%s\n", key.Name, key.Type)
+	}
+	table.Flush()
+}
+
+// configEdit opens the config file in the editor of the user and validates the result. A missing file is created
+// first, so that the editor does not create it with permissions readable by others.
+func configEdit(w io.Writer, file config.File, getenv func(key string) string) error {
+	if _, err := os.Stat(file.Path); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(file.Path), 0o700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file.Path, nil, 0o600); err != nil {
+			return err
+		}
+	}
+
+	editor := editorCommand(getenv)
+	parts := strings.Fields(editor)
+	editorCmd := exec.Command(parts[0], append(parts[1:], file.Path)...)
+	editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("could not run editor %q: %w", editor, err)
+	}
+
+	c, err := file.Load()
+	if err != nil {
+		return err
+	}
+	errs := c.Validate()
+	for _, err := range errs {
+		fmt.Fprintf(w, "Error: %s\n", err)
+	}
+	for _, setting := range c.Settings() {
+		if !setting.Known {
+			fmt.Fprintf(w, "Warning: unknown key %s\n", setting.Key)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s in %s, run config edit again to fix them", plural(len(errs), "invalid setting"), file.Path)
+	}
+
+	return nil
+}
+
+// editorCommand returns $VISUAL or $EDITOR, which may contain arguments like "code --wait".
+// Empty or blank variables fall back to the default editor of the platform.
+func editorCommand(getenv func(key string) string) string {
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(getenv(key)); editor != "" {
+			return editor
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+
+	return "vi"
+}
+
+func init() {
+	rootCmd.AddCommand(newConfigCmd(config.File{Path: configFile()}, os.Getenv))
+}
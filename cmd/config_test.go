@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marvincaspar/clockify2cats/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigCmd(t *testing.T) {
+	file := config.File{Path: filepath.Join(t.TempDir(), "config.yaml")}
+	os.WriteFile(file.Path, []byte("api-key: 0123456789abcdef\nworkspace-id: ws-1\n"), 0o600)
+	configCmd := newConfigCmd(file, func(string) string { return "" })
+
+	run := func(args ...string) string {
+		out := &bytes.Buffer{}
+		cmd, args, err := configCmd.Find(args)
+		assert.NoError(t, err)
+		cmd.SetOut(out)
+		cmd.Run(cmd, args)
+		return out.String()
+	}
+
+	run("set", "locale", "en-US")
+	run("set", "holidays.state", "BY")
+	assert.Equal(t, "en-US\n", run("get", "locale"))
+	assert.Equal(t, "0123456789abcdef\n", run("get", "api-key"))
+	assert.Equal(t, "api-key = ********cdef\nholidays.state = BY\nlocale = en-US\nworkspace-id = ws-1\n", run("list"))
+
+	run("unset", "holidays.state")
+	assert.Equal(t, "api-key = ********cdef\nlocale = en-US\nworkspace-id = ws-1\n", run("list"))
+	assert.Equal(t, file.Path+"\n", run("path"))
+}
+
+func TestConfigCmd_listKeys(t *testing.T) {
+	cmd, _, _ := newConfigCmd(config.File{}, os.Getenv).Find([]string{"list"})
+	defer func() { flagConfigListKeys = false }()
+	flagConfigListKeys = true
+
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.Run(cmd, []string{})
+
+	assert.Contains(t, out.String(), "KEY                       TYPE\n")
+	assert.Contains(t, out.String(), "flex-time-balance         float\n")
+	assert.Contains(t, out.String(), "target-hours              section\n")
+}
+
+func TestConfigGetSetUnset_errors(t *testing.T) {
+	file := config.File{Path: filepath.Join(t.TempDir(), "config.yaml")}
+
+	assert.EqualError(t, configGet(&bytes.Buffer{}, file, "", "locale"), "locale is not set")
+	assert.EqualError(t, configGet(&bytes.Buffer{}, file, "", "lokale"), `unknown key "lokale", see config list --keys`)
+	assert.EqualError(t, configSet(file, "", "hours-format", "hours"), `invalid value "hours" for hours-format: must be one of decimal, hhmm, minutes`)
+	assert.EqualError(t, configUnset(file, "", "locale"), "locale is not set")
+
+	_, err := os.Stat(file.Path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestConfigList_unknownKeys(t *testing.T) {
+	file := config.File{Path: filepath.Join(t.TempDir(), "config.yaml")}
+	os.WriteFile(file.Path, []byte("lokale: en-US\n"), 0o600)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, configList(out, file, ""))
+	assert.Equal(t, "lokale = en-US  # unknown key\n", out.String())
+}
+
+func TestConfigEdit(t *testing.T) {
+	dir := t.TempDir()
+	file := config.File{Path: filepath.Join(dir, "clockify2cats", "config.yaml")}
+	editor := filepath.Join(dir, "editor.sh")
+	os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'hours-format: hours\\nlokale: en-US\\n' >> \"$1\"\n"), 0o755)
+	getenv := func(key string) string {
+		if key == "EDITOR" {
+			return editor
+		}
+		return ""
+	}
+
+	out := &bytes.Buffer{}
+	err := configEdit(out, file, getenv)
+	assert.EqualError(t, err, "1 invalid setting in "+file.Path+", run config edit again to fix them")
+	assert.Equal(t, "Error: invalid value \"hours\" for hours-format: must be one of decimal, hhmm, minutes\nWarning: unknown key lokale\n", out.String())
+
+	info, err := os.Stat(file.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestEditorCommand(t *testing.T) {
+	env := map[string]string{"VISUAL": " ", "EDITOR": "code --wait"}
+	assert.Equal(t, "code --wait", editorCommand(func(key string) string { return env[key] }))
+
+	env["EDITOR"] = "\t"
+	assert.NotEmpty(t, strings.Fields(editorCommand(func(key string) string { return env[key] })), "blank variables use the default editor")
+}
+
+func TestConfigGetSetList_profile(t *testing.T) {
+	file := config.File{Path: filepath.Join(t.TempDir(), "config.yaml")}
+	os.WriteFile(file.Path, []byte("api-key: 0123456789abcdef\nformat: csv\n"), 0o600)
+
+	assert.EqualError(t, configGet(&bytes.Buffer{}, file, "client-b", "format"), `unknown profile "client-b", the config file has no profiles`)
+	assert.NoError(t, configSet(file, "client-b", "format", "json"))
+	assert.NoError(t, configSet(file, "client-b", "api-key", "fedcba9876543210"))
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, configGet(out, file, "client-b", "format"))
+	assert.Equal(t, "json\n", out.String())
+
+	out = &bytes.Buffer{}
+	assert.NoError(t, configList(out, file, "client-b"))
+	assert.Equal(t, "api-key = ********3210\nformat = json\n", out.String())
+
+	out = &bytes.Buffer{}
+	assert.NoError(t, configList(out, file, ""))
+	assert.Equal(t, "api-key = ********cdef\nformat = csv\nprofiles.client-b.api-key = ********3210\nprofiles.client-b.format = json\n", out.String())
+
+	assert.EqualError(t, configUnset(file, "client-c", "format"), `unknown profile "client-c", must be one of client-b`)
+}
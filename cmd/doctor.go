@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/doctor"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newDoctorCmd(d *doctor.Doctor) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the configuration and environment",
+		Long:  `Check the config file and its permissions, the workspace, user and api key settings, the access to the Clockify API, the clipboard tools and the time zone. Every problem is printed with a fix, the command exits with status 1 if a check failed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			checks := d.Run()
+			printChecks(cmd.OutOrStdout(), checks)
+
+			if doctor.Failures(checks) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func printChecks(w io.Writer, checks []doctor.Check) {
+	for _, check := range checks {
+		fmt.Fprintf(w, "%-4s  %-22s %s\n", check.Status, check.Name, check.Message)
+		if check.Fix != "" && check.Status != doctor.OK {
+			fmt.Fprintf(w, "      Fix: %s\n", check.Fix)
+		}
+	}
+
+	if failures := doctor.Failures(checks); failures > 0 {
+		fmt.Fprintf(w, "\n%s failed\n", plural(failures, "check"))
+	} else {
+		fmt.Fprintln(w, "\nNo problems found")
+	}
+}
+
+func init() {
+	var d doctor.Doctor
+	rootCmd.AddCommand(newDoctorCmd(&d))
+	configHooks = append(configHooks, func() {
+		d = doctor.Doctor{
+			ConfigFile:  configFile(),
+			Profile:     flagProfile,
+			WorkspaceID: viper.GetString("workspace-id"),
+			UserID:      viper.GetString("user-id"),
+			ApiKey:      viper.GetString("api-key"),
+			Clockify: report.Repository{
+				WorkspaceID: viper.GetString("workspace-id"),
+				UserID:      viper.GetString("user-id"),
+				ApiKey:      viper.GetString("api-key"),
+				HTTPClient:  &http.Client{Timeout: 10 * time.Second},
+			},
+			GOOS:     runtime.GOOS,
+			Getenv:   os.Getenv,
+			LookPath: exec.LookPath,
+			Now:      time.Now(),
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marvincaspar/clockify2cats/internal/doctor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintChecks(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printChecks(buf, []doctor.Check{
+		{Name: "Config file", Status: doctor.OK, Message: "/home/jane/.config/clockify2cats/config.yaml"},
+		{Name: "Clockify API", Status: doctor.Failed, Message: "the api key was rejected (401 Unauthorized)", Fix: "check api-key"},
+	})
+
+	assert.Equal(t, "OK    Config file            /home/jane/.config/clockify2cats/config.yaml\n"+
+		"FAIL  Clockify API           the api key was rejected (401 Unauthorized)\n"+
+		"      Fix: check api-key\n"+
+		"\n1 check failed\n", buf.String())
+}
+
+func TestPrintChecks_noProblems(t *testing.T) {
+	buf := new(bytes.Buffer)
+	printChecks(buf, []doctor.Check{{Name: "Clipboard", Status: doctor.OK, Message: "xclip"}})
+
+	assert.Contains(t, buf.String(), "No problems found")
+}
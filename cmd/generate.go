@@ -2,10 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/atotto/clipboard"
+	"github.com/marvincaspar/clockify2cats/internal/flextime"
+	"github.com/marvincaspar/clockify2cats/internal/history"
+	"github.com/marvincaspar/clockify2cats/internal/holiday"
+	fileoutput "github.com/marvincaspar/clockify2cats/internal/output"
 	"github.com/marvincaspar/clockify2cats/internal/report"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -13,101 +22,698 @@ import (
 
 var (
 	flagWeek            int
+	flagWeekYear        int
+	flagYear            int
+	flagDate            string
 	flagLastWeek        bool
 	flagCurrentWeek     bool
 	flagMonthChange     string
 	flagCopyToClipboard bool
 	flagCategory        string
 	flagWithText        bool
+	flagFormat          string
+	flagPreview         bool
+	flagOutput          string
+	flagOutputDir       string
+	flagLocale          string
+	flagHoursFormat     string
+	flagMonth           string
+	flagFrom            string
+	flagTo              string
+	flagCombine         bool
+	flagSubmit          bool
+	flagDeltaAgainst    string
+	flagDeltaGrid       bool
+
+	formatOptions report.FormatOptions
+
+	flexTimeStore   flextime.Store
+	flexTimeOpening float64
+
+	historyStore history.Store
+	// clockifyRepository and clockifyReporter are configured by configureGenerate and shared by the commands
+	// reading from Clockify.
+	clockifyRepository report.Repository
+	clockifyReporter   report.Reporter
 )
 
 func newGenerateCmd(t time.Time, reporter report.ReporterInterface) *cobra.Command {
 	return &cobra.Command{
 		Use:   "generate",
 		Short: "Generate report for a specific week",
-		Long:  `Generate a report from your clockify data for a specific week and print it to stdout. You can also copy it to the clipboard.`,
+		Long:  `Generate a report from your clockify data for a specific week, month or date range and print it to stdout. You can also copy it to the clipboard.`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flagLocale != "" {
+				locale, err := report.NewLocale(flagLocale)
+				if err != nil {
+					return fmt.Errorf("invalid value %q for --locale: must be a language tag like \"de-DE\" or \"en-US\"", flagLocale)
+				}
+				formatOptions.Locale = locale
+			}
+			hoursFormat, err := report.ParseHoursFormat(flagHoursFormat)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for --hours-format: must be \"decimal\", \"hhmm\" or \"minutes\"", flagHoursFormat)
+			}
+			formatOptions.Locale = formatOptions.Locale.WithHoursFormat(hoursFormat)
+
 			if flagWeek > 53 {
 				return fmt.Errorf("invalid value %d for --week: must be between 1 and 53", flagWeek)
 			}
+			if flagYear < 0 {
+				return fmt.Errorf("invalid value %d for --year: must be a year like 2024", flagYear)
+			}
+			if flagYear > 0 && flagWeek == 0 {
+				return fmt.Errorf("--year requires --week")
+			}
+			if flagYear > 0 && flagWeekYear > 0 && flagYear != flagWeekYear {
+				return fmt.Errorf("--year %d does not match --week %04d-W%02d", flagYear, flagWeekYear, flagWeek)
+			}
+			if flagDate != "" {
+				if _, err := time.Parse("2006-01-02", flagDate); err != nil {
+					return fmt.Errorf("invalid value %q for --date: must be YYYY-MM-DD", flagDate)
+				}
+			}
+			if year := explicitYear(); year > 0 && flagWeek > weeksInYear(year) {
+				return fmt.Errorf("invalid value %d for --week: %d only has %d weeks", flagWeek, year, weeksInYear(year))
+			}
 			if flagMonthChange != "" && flagMonthChange != "start" && flagMonthChange != "end" {
 				return fmt.Errorf("invalid value %q for --month-boundary: must be \"start\" or \"end\"", flagMonthChange)
 			}
+			_, _, isRange, err := selectedRange()
+			if err != nil {
+				return err
+			}
+			if isRange && flagMonthChange != "" {
+				return fmt.Errorf("--month-boundary can not be used with --month or --from/--to, the edges of the range are filtered automatically")
+			}
+			if flagCombine && !isRange {
+				return fmt.Errorf("--combine requires --month or --from/--to")
+			}
+			if flagCombine && flagSubmit {
+				return fmt.Errorf("--submit can not be used with --combine, submit the weeks one by one")
+			}
+			if flagDeltaGrid && flagDeltaAgainst == "" {
+				return fmt.Errorf("--delta-grid requires --delta-against")
+			}
+			if flagDeltaAgainst != "" && isRange {
+				return fmt.Errorf("--delta-against requires a single week")
+			}
+			if flagDeltaAgainst != "" && flagSubmit {
+				return fmt.Errorf("--submit can not be used with --delta-against, submit the corrected week without it")
+			}
+			if isRange && !flagCombine && flagOutput != "" && !strings.Contains(strings.ToLower(flagOutput), "{week}") {
+				return fmt.Errorf("--output %q needs a {week} placeholder to write one file per week, or use --combine", flagOutput)
+			}
+			if _, err := report.NewFormatter(flagFormat, formatOptions); err != nil {
+				return fmt.Errorf("invalid value %q for --format: must be one of %s", flagFormat, strings.Join(report.FormatterNames(), ", "))
+			}
+			if _, err := (fileoutput.Placeholders{}).Expand(flagOutput); err != nil {
+				return fmt.Errorf("invalid value %q for --output: %s", flagOutput, err)
+			}
+			if strings.EqualFold(flagFormat, "template") {
+				if err := formatOptions.Template.Validate(); err != nil {
+					return err
+				}
+			}
+			if strings.EqualFold(flagFormat, "xlsx") {
+				if err := formatOptions.XLSX.Validate(); err != nil {
+					return err
+				}
+			}
+			if report.IsBinaryFormat(flagFormat) && flagOutput == "" && flagOutputDir == "" {
+				return fmt.Errorf("format %q requires --output or --output-dir", flagFormat)
+			}
+			if report.IsBinaryFormat(flagFormat) && flagCopyToClipboard {
+				return fmt.Errorf("format %q can not be copied to the clipboard", flagFormat)
+			}
 			return nil
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			var week int
-			year, currentWeek := t.ISOWeek()
-
-			if flagCurrentWeek {
-				week = currentWeek
-			} else if flagLastWeek {
-				week = currentWeek - 1
-
-				// if the current week is the first week of the year we need to go back to the previous year
-				if currentWeek == 1 {
-					year, currentWeek = t.Add(-time.Hour * 24 * 7).ISOWeek()
-					week = currentWeek
-				}
-			} else if flagWeek > 0 {
-				weekInput := flagWeek
+			reports, err := generateReports(t, reporter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			if flagCombine {
+				reports = []report.Report{report.Combine(reports)}
+			}
 
-				if weekInput > currentWeek {
-					year = year - 1
+			locale := formatOptions.Locale
+			if flagDeltaAgainst != "" {
+				correction, ok, err := deltaReport(locale, reports[0])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					os.Exit(1)
 				}
-
-				week = weekInput
-
-			} else {
-				fmt.Fprintf(os.Stderr, "Error: no week specified")
-				os.Exit(1)
+				if !ok {
+					return
+				}
+				reports[0] = correction
 			}
 
-			report, totalHours, err := reporter.Generate(
-				year,
-				week,
-				flagCategory,
-				flagWithText,
-				flagMonthChange,
-			)
+			formatOptions.Now = time.Now()
+			formatter, err := report.NewFormatter(flagFormat, formatOptions)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 				os.Exit(1)
 			}
 
-			fmt.Println(report)
+			clipboardContent := []string{}
+			for i, generated := range reports {
+				if len(reports) > 1 {
+					if i > 0 {
+						fmt.Println()
+					}
+					fmt.Printf("Week %s (%s – %s)\n", generated.Label(), locale.FormatDate(generated.Period.From), locale.FormatDate(generated.Period.To))
+				}
+
+				var output strings.Builder
+				if err := formatter.Format(&output, generated); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+					os.Exit(1)
+				}
+
+				if flagPreview {
+					var preview strings.Builder
+					if err := (report.PreviewFormatter{Color: colorEnabled(), Locale: locale}).Format(&preview, generated); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+						os.Exit(1)
+					}
+					fmt.Println(preview.String())
+				} else if flagOutput == "" && flagOutputDir == "" {
+					fmt.Println(output.String())
+				}
+
+				if path := outputPath(generated); path != "" {
+					if err := fileoutput.WriteFile(path, []byte(output.String()), 0o644); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: could not write report: %s\n", err)
+						os.Exit(1)
+					}
+					fmt.Printf("Report written to %s\n", path)
+				}
+				clipboardContent = append(clipboardContent, output.String())
+
+				// the totals and flex-time of a correction grid would only cover the changed rows
+				if flagDeltaAgainst == "" {
+					printSummary(summaryWriter(), t, locale, generated)
+				}
+				if flagSubmit {
+					recordSubmission(t, generated)
+				}
+			}
 
 			if flagCopyToClipboard {
-				if err := clipboard.WriteAll(report); err != nil {
+				if err := clipboard.WriteAll(strings.Join(clipboardContent, "\n")); err != nil {
 					fmt.Fprintf(os.Stderr, "Error: could not copy to clipboard: %s\nMake sure xclip, xsel (X11) or wl-clipboard (Wayland) is installed.\n", err)
 				}
 			}
-
-			fmt.Printf("Total: %.2fh\n", totalHours)
 		},
 	}
 }
 
-func init() {
-	initConfig()
+// selectedWeek returns the ISO year and week selected with --current, --last, --week or --date.
+// A week number after the current week refers to the previous year, unless the year is given with
+// --year or in ISO notation like 2024-W10.
+func selectedWeek(t time.Time) (int, int, error) {
+	var week int
+	year, currentWeek := t.ISOWeek()
+
+	if flagDate != "" {
+		date, err := time.Parse("2006-01-02", flagDate)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid value %q for --date: must be YYYY-MM-DD", flagDate)
+		}
+		year, week = date.ISOWeek()
+	} else if flagCurrentWeek {
+		week = currentWeek
+	} else if flagLastWeek {
+		week = currentWeek - 1
+
+		// if the current week is the first week of the year we need to go back to the previous year
+		if currentWeek == 1 {
+			year, currentWeek = t.Add(-time.Hour * 24 * 7).ISOWeek()
+			week = currentWeek
+		}
+	} else if flagWeek > 0 {
+		weekInput := flagWeek
+
+		if explicitYear() > 0 {
+			year = explicitYear()
+		} else if weekInput > currentWeek {
+			year = year - 1
+		}
+
+		week = weekInput
+
+	} else {
+		return 0, 0, fmt.Errorf("no week specified")
+	}
+
+	return year, week, nil
+}
+
+// explicitYear returns the year given with --year or in the ISO notation of --week, 0 if there is none.
+func explicitYear() int {
+	if flagYear > 0 {
+		return flagYear
+	}
+
+	return flagWeekYear
+}
+
+// weeksInYear returns the number of ISO weeks of the year, December 28th always lies in the last week.
+func weeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+
+	return week
+}
+
+// weekValue parses --week as a week number like 10 or in ISO notation like 2024-W10.
+type weekValue struct{}
+
+func (weekValue) String() string {
+	if flagWeek == 0 {
+		return ""
+	}
+	if flagWeekYear > 0 {
+		return fmt.Sprintf("%04d-W%02d", flagWeekYear, flagWeek)
+	}
+
+	return strconv.Itoa(flagWeek)
+}
+
+func (weekValue) Set(value string) error {
+	year, week := 0, value
+	if before, after, found := strings.Cut(strings.ToUpper(value), "W"); found {
+		parsed, err := strconv.Atoi(strings.TrimSuffix(before, "-"))
+		if err != nil || parsed < 1 {
+			return fmt.Errorf("must be a week number like 10 or an ISO week like 2024-W10")
+		}
+		year, week = parsed, after
+	}
+
+	number, err := strconv.Atoi(week)
+	if err != nil || number < 1 {
+		return fmt.Errorf("must be a week number like 10 or an ISO week like 2024-W10")
+	}
+	flagWeek, flagWeekYear = number, year
+
+	return nil
+}
+
+func (weekValue) Type() string {
+	return "week"
+}
+
+// selectedRange returns the days selected with --month or --from and --to.
+func selectedRange() (time.Time, time.Time, bool, error) {
+	if flagMonth != "" {
+		month, err := time.Parse("2006-01", flagMonth)
+		if err != nil {
+			return month, month, false, fmt.Errorf("invalid value %q for --month: must be YYYY-MM", flagMonth)
+		}
+
+		return month, month.AddDate(0, 1, -1), true, nil
+	}
+
+	if flagFrom == "" && flagTo == "" {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	from, err := time.Parse("2006-01-02", flagFrom)
+	if err != nil {
+		return from, from, false, fmt.Errorf("invalid value %q for --from: must be YYYY-MM-DD", flagFrom)
+	}
+	to, err := time.Parse("2006-01-02", flagTo)
+	if err != nil {
+		return from, to, false, fmt.Errorf("invalid value %q for --to: must be YYYY-MM-DD", flagTo)
+	}
+	if to.Before(from) {
+		return from, to, false, fmt.Errorf("invalid range: --to %s is before --from %s", flagTo, flagFrom)
+	}
+
+	return from, to, true, nil
+}
+
+// generateReports generates the report of the selected week or one report per week of the selected range.
+func generateReports(t time.Time, reporter report.ReporterInterface) ([]report.Report, error) {
+	from, to, isRange, err := selectedRange()
+	if err != nil {
+		return nil, err
+	}
+	if isRange {
+		return reporter.GenerateRange(from, to, flagCategory, flagWithText)
+	}
+
+	year, week, err := selectedWeek(t)
+	if err != nil {
+		return nil, err
+	}
+
+	generated, err := reporter.Generate(year, week, flagCategory, flagWithText, flagMonthChange)
+	if err != nil {
+		return nil, err
+	}
+
+	return []report.Report{generated}, nil
+}
+
+// printSummary prints the total, break deductions, warnings and target hours below the report.
+func printSummary(w io.Writer, t time.Time, locale report.Locale, generated report.Report) {
+	summary := generated.Summary
+
+	fmt.Fprintf(w, "Total: %s%s\n", locale.FormatHoursWithUnit(generated.DisplayedTotal(locale.HoursFormat())), formatExcluded(locale, summary.Excluded))
+
+	for _, deduction := range summary.Deductions {
+		fmt.Fprintf(w, "Break deducted: %s from %s on %s %s (worked %s, break %s, required %s)\n",
+			locale.FormatHoursWithUnit(deduction.Duration.Hours()), deduction.CatsID,
+			locale.FormatWeekday(deduction.Date), locale.FormatDate(deduction.Date),
+			locale.FormatHoursWithUnit(deduction.Worked.Hours()), locale.FormatHoursWithUnit(deduction.Taken.Hours()),
+			locale.FormatHoursWithUnit(deduction.Required.Hours()))
+	}
+
+	for _, warning := range summary.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if summary.HasTarget {
+		printTargetSummary(w, t, locale, generated)
+	}
+}
+
+// summaryWriter returns where the summary is printed: stderr if a machine-readable report is printed to stdout,
+// so that e.g. the json output can be piped into jq.
+func summaryWriter() io.Writer {
+	if report.IsMachineReadableFormat(flagFormat) && flagOutput == "" && flagOutputDir == "" && !flagPreview {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
+// outputPath returns the file the report is written to, or an empty string to print it to stdout.
+// In --output-dir every week gets its own file, weeks spanning a month boundary get one file per half
+// and combined reports are named after their first and last day.
+func outputPath(generated report.Report) string {
+	pattern := flagOutput
+	if flagOutputDir != "" {
+		name := "{year}-W{week}"
+		if flagCombine {
+			name = generated.Period.From.Format("2006-01-02") + "_" + generated.Period.To.Format("2006-01-02")
+		} else if boundary, ok := generated.MonthBoundary(); !ok {
+			name += "-partial"
+		} else if boundary != "" {
+			name += "-" + boundary
+		} else if flagMonthChange != "" {
+			name += "-" + flagMonthChange
+		}
+		pattern = filepath.Join(flagOutputDir, name+report.FileExtension(flagFormat))
+	}
+
+	// the pattern is validated in PreRunE
+	path, _ := fileoutput.Placeholders{Year: generated.Year, Week: generated.Week, Profile: flagProfile}.Expand(pattern)
+	return path
+}
+
+// colorEnabled reports whether stdout is a terminal and colors are not disabled with NO_COLOR.
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// formatExcluded lists the excluded hours by reason, e.g. " (excluded: 1,00h break, 0,50h no-cats)".
+func formatExcluded(locale report.Locale, excluded map[string]float64) string {
+	if len(excluded) == 0 {
+		return ""
+	}
+
+	reasons := make([]string, 0, len(excluded))
+	for reason := range excluded {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%s %s", locale.FormatHoursWithUnit(excluded[reason]), reason)
+	}
+
+	return " (excluded: " + strings.Join(parts, ", ") + ")"
+}
+
+// printTargetSummary prints the per-day and weekly deltas to the contracted hours and the flex-time balance.
+// The delta of a week is only persisted to the flex-time ledger once the week is over.
+func printTargetSummary(w io.Writer, t time.Time, locale report.Locale, generated report.Report) {
+	summary := generated.Summary
+	if summary.Absence > 0 {
+		fmt.Fprintf(w, "Absence: %s\n", locale.FormatHoursWithUnit(summary.Absence))
+	}
+	fmt.Fprintf(w, "Target: %s (%s)\n", locale.FormatHoursWithUnit(summary.Target), locale.FormatSignedHoursWithUnit(summary.Delta()))
+	for _, day := range summary.Days {
+		fmt.Fprintf(w, "  %s %s: %s", locale.FormatWeekday(day.Date), locale.FormatDate(day.Date), locale.FormatHoursWithUnit(day.Hours))
+		if day.Absence > 0 {
+			fmt.Fprintf(w, " + %s absence", locale.FormatHoursWithUnit(day.Absence))
+		}
+		fmt.Fprintf(w, " / %s (%s)", locale.FormatHoursWithUnit(day.Target), locale.FormatSignedHoursWithUnit(day.Delta()))
+		if day.Holiday != "" {
+			fmt.Fprintf(w, " %s", day.Holiday)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(summary.Days) == 0 {
+		return
+	}
+
+	ledger, err := flexTimeStore.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not load flex-time balance: %s\n", err)
+		return
+	}
+
+	key, ok := flexTimeKey(generated)
+	if !ok {
+		fmt.Fprintln(w, "Flex-time balance: not saved for combined reports and partial weeks")
+		return
+	}
+
+	weekEnd := summary.Days[len(summary.Days)-1].Date.AddDate(0, 0, 1)
+	if t.Before(weekEnd) {
+		fmt.Fprintf(w, "Flex-time balance: %s (week in progress, not saved)\n", locale.FormatSignedHoursWithUnit(ledger.Balance(flexTimeOpening, key, summary.Delta())))
+		return
+	}
+
+	if err := flexTimeStore.Record(key, summary.Delta()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not save flex-time balance: %s\n", err)
+		return
+	}
+	fmt.Fprintf(w, "Flex-time balance: %s\n", locale.FormatSignedHoursWithUnit(ledger.Balance(flexTimeOpening, key, summary.Delta())))
+}
+
+// flexTimeKey returns the ledger key of a report. Only whole weeks and the parts of a week before or after
+// a month boundary are recorded, so that every day is counted once.
+func flexTimeKey(generated report.Report) (string, bool) {
+	boundary, ok := monthBoundary(generated)
+	if !ok {
+		return "", false
+	}
+
+	return flextime.WeekKey(generated.Year, generated.Week, boundary), true
+}
+
+// monthBoundary returns "" for a whole week and "start" or "end" for a half of a week spanning a month boundary.
+// Combined reports and other partial weeks have no boundary.
+func monthBoundary(generated report.Report) (string, bool) {
+	if flagCombine {
+		return "", false
+	}
+
+	// --month-boundary on a week within one month still covers the whole week
+	return generated.MonthBoundary()
+}
+
+// deltaReport compares the report with the submission or json report given with --delta-against.
+// Without --delta-grid it prints the changed cells and returns false, otherwise it returns the correction grid.
+func deltaReport(locale report.Locale, generated report.Report) (report.Report, bool, error) {
+	source, label, err := loadDeltaSource(locale, flagDeltaAgainst)
+	if err != nil {
+		return generated, false, err
+	}
+	if source.Year != generated.Year || source.Week != generated.Week {
+		return generated, false, fmt.Errorf("%s is week %04d-W%02d, not %04d-W%02d", label, source.Year, source.Week, generated.Year, generated.Week)
+	}
+	if source.WithText && !generated.WithText {
+		return generated, false, fmt.Errorf("%s has text columns, generate the week with --text", label)
+	}
+	if !source.WithText && generated.WithText {
+		return generated, false, fmt.Errorf("%s has no text columns, generate the week without --text", label)
+	}
+	boundary, _ := monthBoundary(generated)
+	current := history.FromReport(generated, boundary)
+	if strings.Join(source.Days, ",") != strings.Join(current.Days, ",") {
+		if source.MonthBoundary != boundary {
+			return generated, false, fmt.Errorf("%s was generated with --month-boundary %q", label, source.MonthBoundary)
+		}
+		return generated, false, fmt.Errorf("%s covers other days than the report", label)
+	}
+
+	changes := history.Compare(source, current)
+	if len(changes) == 0 {
+		fmt.Printf("No changes since %s\n", label)
+		return generated, false, nil
+	}
+	if flagDeltaGrid {
+		return history.CorrectionReport(generated, changes), true, nil
+	}
+
+	fmt.Printf("Changes since %s:\n", label)
+	printChanges(os.Stdout, locale, changes)
+	fmt.Printf("Total: %s → %s\n", locale.FormatHoursWithUnit(source.Total), locale.FormatHoursWithUnit(current.Total))
+
+	return generated, false, nil
+}
+
+// loadDeltaSource loads a submission of the history by ID (like 3 or #3) or a report file of the json format.
+func loadDeltaSource(locale report.Locale, value string) (history.Submission, string, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(value, "#")); err == nil {
+		loaded, err := historyStore.Load()
+		if err != nil {
+			return history.Submission{}, "", err
+		}
+		submission, ok := loaded.Find(id)
+		if !ok {
+			return submission, "", fmt.Errorf("no submission #%d in the history, see status", id)
+		}
+
+		return submission, fmt.Sprintf("submission #%d of %s", submission.ID, locale.FormatDate(submission.SubmittedAt)), nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return history.Submission{}, "", fmt.Errorf("could not read --delta-against: %w", err)
+	}
+	submission, err := history.ParseSubmission(data)
+	if err != nil {
+		return submission, "", fmt.Errorf("could not read --delta-against %s: %w", value, err)
+	}
+
+	return submission, value, nil
+}
+
+// recordSubmission records the report as submitted to CATS in the local history, so that status can
+// detect later changes in Clockify.
+func recordSubmission(t time.Time, generated report.Report) {
+	boundary, ok := monthBoundary(generated)
+	if !ok {
+		fmt.Println("Submission: not recorded for combined reports and partial weeks")
+		return
+	}
+
+	submission := history.FromReport(generated, boundary)
+	submission.SubmittedAt = t
+	submission, err := historyStore.Record(submission)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not record submission: %s\n", err)
+		return
+	}
+	fmt.Printf("Submission: recorded as #%d\n", submission.ID)
+}
+
+// configureGenerate reads the settings of the selected profile. The flags given on the command line take
+// precedence over the defaults of the config file.
+func configureGenerate(generateCmd *cobra.Command, t time.Time) {
 	workspaceID := viper.GetString("workspace-id")
 	userID := viper.GetString("user-id")
 	apiKey := viper.GetString("api-key")
 	descriptionDelimiter := viper.GetString("description-delimiter")
 
-	clockifyRepository := report.Repository{
+	var schedules report.Schedules
+	if err := viper.UnmarshalKey("target-hours", &schedules); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid target-hours config: %s\n", err)
+	}
+	if err := schedules.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		schedules = nil
+	}
+
+	var breakRules report.BreakRules
+	if err := viper.UnmarshalKey("breaks", &breakRules); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid breaks config: %s\n", err)
+	}
+
+	if err := viper.UnmarshalKey("xlsx", &formatOptions.XLSX); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid xlsx config: %s\n", err)
+	}
+	if err := viper.UnmarshalKey("records", &formatOptions.Records); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid records config: %s\n", err)
+	}
+	templateFile := formatOptions.Template.File
+	if err := viper.UnmarshalKey("template", &formatOptions.Template); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid template config: %s\n", err)
+	}
+	if generateCmd.Flags().Changed("template") {
+		formatOptions.Template.File = templateFile
+	}
+
+	calendar := holiday.Calendar{State: viper.GetString("holidays.state")}
+	if calendar.State != "" {
+		if _, err := holiday.ForState(calendar.State, t.Year()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid holidays.state config: %s\n", err)
+			calendar.State = ""
+		}
+	}
+	for _, file := range viper.GetStringSlice("holidays.files") {
+		custom, err := holiday.LoadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not load holidays file: %s\n", err)
+			continue
+		}
+		calendar.Custom = append(calendar.Custom, custom...)
+	}
+
+	flexTimeStore = flextime.Store{Path: filepath.Join(dataDir(), "flextime.json")}
+	flexTimeOpening = viper.GetFloat64("flex-time-balance")
+	historyStore = history.Store{Path: filepath.Join(dataDir(), "history.json")}
+
+	clockifyRepository = report.Repository{
 		WorkspaceID: workspaceID,
 		UserID:      userID,
 		ApiKey:      apiKey,
 	}
 
-	reporter := report.Reporter{
+	clockifyReporter = report.Reporter{
 		Repository:           clockifyRepository,
 		DescriptionDelimiter: descriptionDelimiter,
+		Schedules:            schedules,
+		Holidays:             calendar,
+		HolidayCatsID:        viper.GetString("holidays.cats-id"),
+		AbsenceTypes:         viper.GetStringMapString("time-off.policies"),
+		Breaks:               breakRules,
+		ExcludedTags:         viper.GetStringSlice("exclude-tags"),
 	}
 
+	for flag, value := range map[string]*string{
+		"format":       &flagFormat,
+		"locale":       &flagLocale,
+		"hours-format": &flagHoursFormat,
+		"category":     &flagCategory,
+	} {
+		if configured := viper.GetString(flag); configured != "" && !generateCmd.Flags().Changed(flag) {
+			*value = configured
+		}
+	}
+}
+
+func init() {
 	t := time.Now()
-	generateCmd := newGenerateCmd(t, &reporter)
+	generateCmd := newGenerateCmd(t, &clockifyReporter)
+	configHooks = append(configHooks, func() {
+		configureGenerate(generateCmd, t)
+	})
 
 	rootCmd.AddCommand(generateCmd)
 
@@ -116,18 +722,39 @@ func init() {
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// generateCmd.PersistentFlags().String("foo", "", "A help for foo")
-	generateCmd.Flags().IntVarP(&flagWeek, "week", "w", 0, "Week number")
+	generateCmd.Flags().VarP(weekValue{}, "week", "w", "Week number, or ISO week like 2024-W10")
+	generateCmd.Flags().IntVar(&flagYear, "year", 0, "ISO year of --week, defaults to the last year with that week number")
+	generateCmd.Flags().StringVar(&flagDate, "date", "", "Any day (YYYY-MM-DD) of the week to report")
 	generateCmd.Flags().BoolVarP(&flagLastWeek, "last", "l", false, "Last week")
 	generateCmd.Flags().BoolVarP(&flagCurrentWeek, "current", "c", false, "Current week")
-	generateCmd.MarkFlagsOneRequired("week", "last", "current")
-	generateCmd.MarkFlagsMutuallyExclusive("week", "last", "current")
+	generateCmd.Flags().StringVar(&flagMonth, "month", "", "Month (YYYY-MM), one report per ISO week")
+	generateCmd.Flags().StringVar(&flagFrom, "from", "", "First day of a date range (YYYY-MM-DD), one report per ISO week")
+	generateCmd.Flags().StringVar(&flagTo, "to", "", "Last day of a date range (YYYY-MM-DD)")
+	generateCmd.Flags().BoolVar(&flagCombine, "combine", false, "Combine the weeks of --month or --from/--to into one report")
+	generateCmd.MarkFlagsOneRequired("week", "last", "current", "date", "month", "from")
+	generateCmd.MarkFlagsMutuallyExclusive("week", "last", "current", "date", "month", "from")
+	generateCmd.MarkFlagsMutuallyExclusive("year", "last", "current", "date", "month", "from")
+	generateCmd.MarkFlagsRequiredTogether("from", "to")
+	generateCmd.Flags().BoolVar(&flagSubmit, "submit", false, "Record the report as submitted to CATS in the local history, see status")
+	generateCmd.Flags().StringVar(&flagDeltaAgainst, "delta-against", "", "Print the cells that changed since a submission (ID from status) or a report file of the json format")
+	generateCmd.Flags().BoolVar(&flagDeltaGrid, "delta-grid", false, "Print the changed rows of --delta-against as report to paste over the submitted rows")
 	generateCmd.Flags().StringVarP(&flagMonthChange, "month-boundary", "m", "", `Filter entries for weeks spanning a month boundary: "start" keeps the new month, "end" keeps the current month`)
 
 	generateCmd.Flags().BoolVarP(&flagCopyToClipboard, "copy", "C", false, "Copy report to clipboard")
 
-	generateCmd.Flags().StringVar(&flagCategory, "category", "ID", "Category identifier")
+	generateCmd.Flags().StringVar(&flagCategory, "category", "ID", "Category identifier, defaults to category of the config file")
 	generateCmd.Flags().BoolVarP(&flagWithText, "text", "t", false, "Print with text")
 
+	generateCmd.Flags().BoolVarP(&flagPreview, "preview", "p", false, "Print a readable table instead of the report, the clipboard still receives the report")
+	generateCmd.Flags().StringVarP(&flagFormat, "format", "f", "tsv", "Output format: "+strings.Join(report.FormatterNames(), ", ")+", defaults to format of the config file")
+	generateCmd.Flags().StringVar(&formatOptions.Template.File, "template", "", "Go text/template file for --format template, defaults to template.file of the config file")
+	generateCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "Write the report to a file instead of stdout, supports the placeholders {year}, {week} and {profile}")
+	generateCmd.Flags().StringVar(&flagOutputDir, "output-dir", "", "Write the report of every week to its own file in this directory")
+	generateCmd.MarkFlagsMutuallyExclusive("output", "output-dir")
+
+	generateCmd.Flags().StringVar(&flagLocale, "locale", "de-DE", `Locale of numbers and dates, e.g. "de-DE" or "en-US", defaults to locale of the config file`)
+	generateCmd.Flags().StringVar(&flagHoursFormat, "hours-format", string(report.HoursDecimal), `Display mode of hours: "decimal" (7,50), "hhmm" (7:30) or "minutes" (450), defaults to hours-format of the config file`)
+
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// generateCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
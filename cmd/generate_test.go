@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/marvincaspar/clockify2cats/internal/history"
 	"github.com/marvincaspar/clockify2cats/internal/report"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -88,7 +92,8 @@ func TestGenerateCmd_WithFutureWeekNumber_usesLastYear(t *testing.T) {
 
 	flagCurrentWeek = false
 	flagLastWeek = false
-	flagWeek = 5 // week 5 is before current week 49, so same year
+	// week 5 is before current week 49, so same year
+	flagWeek = 5
 	testTime := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC) // week 49
 	cmd := newGenerateCmd(testTime, m)
 	cmd.Run(cmd, []string{})
@@ -102,7 +107,8 @@ func TestGenerateCmd_WithWeekAheadOfCurrentWeek_usesPreviousYear(t *testing.T) {
 
 	flagCurrentWeek = false
 	flagLastWeek = false
-	flagWeek = 52 // week 52 is after current week 1, so previous year
+	// week 52 is after current week 1, so previous year
+	flagWeek = 52
 	testTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC) // week 1
 	cmd := newGenerateCmd(testTime, m)
 	cmd.Run(cmd, []string{})
@@ -110,6 +116,102 @@ func TestGenerateCmd_WithWeekAheadOfCurrentWeek_usesPreviousYear(t *testing.T) {
 	m.AssertCalled(t, "Generate", 2023, 52, "ID", false, "")
 }
 
+func TestGenerateCmd_WithYear_usesGivenYear(t *testing.T) {
+	m := new(reporterMock)
+	m.On("Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("").Once()
+
+	flagCurrentWeek = false
+	flagLastWeek = false
+	flagWeek = 10
+	flagYear = 2024
+	defer func() { flagWeek = 0; flagYear = 0 }()
+	testTime := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC) // week 43
+	cmd := newGenerateCmd(testTime, m)
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+	cmd.Run(cmd, []string{})
+
+	m.AssertCalled(t, "Generate", 2024, 10, "ID", false, "")
+}
+
+func TestGenerateCmd_WithISOWeek_usesGivenYear(t *testing.T) {
+	m := new(reporterMock)
+	m.On("Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("").Once()
+
+	flagCurrentWeek = false
+	flagLastWeek = false
+	defer func() { flagWeek = 0; flagWeekYear = 0 }()
+	assert.NoError(t, weekValue{}.Set("2024-W10"))
+	assert.Equal(t, "2024-W10", weekValue{}.String())
+	testTime := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	cmd := newGenerateCmd(testTime, m)
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+	cmd.Run(cmd, []string{})
+
+	m.AssertCalled(t, "Generate", 2024, 10, "ID", false, "")
+}
+
+func TestGenerateCmd_WithDate_usesContainingWeek(t *testing.T) {
+	m := new(reporterMock)
+	m.On("Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("").Once()
+
+	flagCurrentWeek = false
+	flagLastWeek = false
+	flagWeek = 0
+	flagDate = "2021-01-03" // Sunday of week 53 of 2020
+	defer func() { flagDate = "" }()
+	testTime := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	cmd := newGenerateCmd(testTime, m)
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+	cmd.Run(cmd, []string{})
+
+	m.AssertCalled(t, "Generate", 2020, 53, "ID", false, "")
+}
+
+func TestWeekValue_Set(t *testing.T) {
+	defer func() { flagWeek = 0; flagWeekYear = 0 }()
+
+	for value, want := range map[string][2]int{"10": {0, 10}, "2024-W10": {2024, 10}, "2024w07": {2024, 7}} {
+		assert.NoError(t, weekValue{}.Set(value), value)
+		assert.Equal(t, want, [2]int{flagWeekYear, flagWeek}, value)
+	}
+
+	for _, value := range []string{"", "ten", "0", "W10", "2024-W", "2024-10"} {
+		assert.EqualError(t, weekValue{}.Set(value), "must be a week number like 10 or an ISO week like 2024-W10", value)
+	}
+}
+
+func TestGenerateCmd_YearFlags_invalidValues(t *testing.T) {
+	flagMonthChange = ""
+	defer func() { flagWeek = 0; flagWeekYear = 0; flagYear = 0; flagDate = "" }()
+
+	tests := []struct {
+		name     string
+		week     int
+		weekYear int
+		year     int
+		date     string
+		want     string
+	}{
+		{name: "year without week", year: 2024, want: "--year requires --week"},
+		{name: "negative year", week: 10, year: -1, want: "invalid value -1 for --year: must be a year like 2024"},
+		{name: "conflicting years", week: 10, weekYear: 2024, year: 2023, want: "--year 2023 does not match --week 2024-W10"},
+		{name: "week 53 in a short year", week: 53, year: 2024, want: "invalid value 53 for --week: 2024 only has 52 weeks"},
+		{name: "invalid date", date: "07.03.2024", want: `invalid value "07.03.2024" for --date: must be YYYY-MM-DD`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagWeek, flagWeekYear, flagYear, flagDate = tt.week, tt.weekYear, tt.year, tt.date
+			cmd := newGenerateCmd(time.Now(), &reporterMock{})
+
+			assert.EqualError(t, cmd.PreRunE(cmd, []string{}), tt.want)
+		})
+	}
+
+	flagWeek, flagWeekYear, flagYear, flagDate = 53, 2020, 0, ""
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+}
+
 func TestGenerateCmd_MonthFlag_validValues(t *testing.T) {
 	for _, valid := range []string{"start", "end", ""} {
 		m := new(reporterMock)
@@ -142,6 +244,194 @@ func TestGenerateCmd_WeekFlag_tooLarge(t *testing.T) {
 	assert.EqualError(t, err, "invalid value 54 for --week: must be between 1 and 53")
 }
 
+func TestGenerateCmd_FormatFlag_invalidValue(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagFormat = "yaml"
+	defer func() { flagFormat = "tsv" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `invalid value "yaml" for --format: must be one of csv, html, ics, json, markdown, records, template, tsv, xlsx`)
+}
+
+func TestGenerateCmd_LocaleFlag_invalidValue(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagLocale = "de_DE!"
+	defer func() { flagLocale = "" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `invalid value "de_DE!" for --locale: must be a language tag like "de-DE" or "en-US"`)
+}
+
+func TestGenerateCmd_HoursFormatFlag_invalidValue(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagHoursFormat = "seconds"
+	defer func() { flagHoursFormat = "" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `invalid value "seconds" for --hours-format: must be "decimal", "hhmm" or "minutes"`)
+}
+
+func TestGenerateCmd_FormatFlag_templateRequiresFile(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagFormat = "template"
+	template := formatOptions.Template
+	formatOptions.Template = report.TemplateOptions{}
+	defer func() { flagFormat = "tsv"; formatOptions.Template = template }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `format "template" requires a template file: set template.file in the config or pass --template`)
+}
+
+func TestGenerateCmd_FormatFlag_binaryFormatRequiresOutput(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagFormat = "xlsx"
+	defer func() { flagFormat = "tsv"; flagOutput = "" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `format "xlsx" requires --output or --output-dir`)
+
+	flagOutput = "report.xlsx"
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+}
+
+func TestGenerateCmd_FormatFlag_invalidXLSXColumns(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagFormat = "xlsx"
+	flagOutput = "report.xlsx"
+	xlsx := formatOptions.XLSX
+	formatOptions.XLSX = report.XLSXOptions{Columns: []report.XLSXColumn{{Field: "hours"}}}
+	defer func() { flagFormat = "tsv"; flagOutput = ""; formatOptions.XLSX = xlsx }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.ErrorContains(t, err, `unknown xlsx column field "hours"`)
+}
+
+func TestGenerateCmd_OutputFlag_unknownPlaceholder(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	flagOutput = "{year}-{month}.tsv"
+	defer func() { flagOutput = "" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `invalid value "{year}-{month}.tsv" for --output: unknown placeholder "{month}": must be one of {profile}, {week}, {year}`)
+}
+
+func TestOutputPath(t *testing.T) {
+	defer func() { flagOutput = ""; flagOutputDir = ""; flagFormat = "tsv"; flagMonthChange = "" }()
+
+	generated := report.Report{Year: 2026, Week: 9}
+	assert.Equal(t, "", outputPath(generated))
+
+	flagOutput = "cats-{profile}-{year}-W{week}.tsv"
+	assert.Equal(t, "cats-default-2026-W09.tsv", outputPath(generated))
+
+	flagOutput = ""
+	flagOutputDir = "archive"
+	flagFormat = "markdown"
+	assert.Equal(t, filepath.Join("archive", "2026-W09.md"), outputPath(generated))
+
+	flagMonthChange = "end"
+	assert.Equal(t, filepath.Join("archive", "2026-W09-end.md"), outputPath(generated))
+}
+
+func TestOutputPath_range(t *testing.T) {
+	defer func() { flagOutputDir = ""; flagFormat = "tsv"; flagCombine = false }()
+	flagOutputDir = "archive"
+
+	monthEnd := report.Report{Year: 2026, Week: 36, Period: report.Period{From: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)}}
+	assert.Equal(t, filepath.Join("archive", "2026-W36-end.tsv"), outputPath(monthEnd))
+
+	partial := report.Report{Year: 2026, Week: 36, Period: report.Period{From: time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 9, 6, 0, 0, 0, 0, time.UTC)}}
+	assert.Equal(t, filepath.Join("archive", "2026-W36-partial.tsv"), outputPath(partial))
+
+	flagCombine = true
+	combined := report.Report{Year: 2026, Week: 36, Period: report.Period{From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)}}
+	assert.Equal(t, filepath.Join("archive", "2026-09-01_2026-09-30.tsv"), outputPath(combined))
+}
+
+func TestFlexTimeKey(t *testing.T) {
+	defer func() { flagMonthChange = "" }()
+	flagMonthChange = "end"
+
+	week := report.Report{Year: 2026, Week: 7, Period: report.Period{From: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)}}
+	key, ok := flexTimeKey(week)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-W07", key, "a week within one month is recorded as whole week")
+
+	monthEnd := report.Report{Year: 2026, Week: 36, Period: report.Period{From: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)}}
+	key, ok = flexTimeKey(monthEnd)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-W36/end", key)
+}
+
+func TestGenerateCmd_WithMonth_generatesRange(t *testing.T) {
+	m := new(reporterMock)
+	m.On("GenerateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]report.Report{}).Once()
+
+	flagCurrentWeek = false
+	flagLastWeek = false
+	flagWeek = 0
+	flagMonth = "2026-09"
+	defer func() { flagMonth = "" }()
+
+	cmd := newGenerateCmd(time.Now(), m)
+	cmd.Run(cmd, []string{})
+
+	m.AssertCalled(t, "GenerateRange", time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), "ID", false)
+	m.AssertNotCalled(t, "Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateCmd_RangeFlags_invalidValues(t *testing.T) {
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	flagWeek = 0
+	flagMonthChange = ""
+	defer func() {
+		flagMonth = ""
+		flagFrom = ""
+		flagTo = ""
+		flagMonthChange = ""
+		flagCombine = false
+		flagOutput = ""
+	}()
+
+	flagMonth = "09/2026"
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), `invalid value "09/2026" for --month: must be YYYY-MM`)
+
+	flagMonth = ""
+	flagFrom = "2026-09-30"
+	flagTo = "2026-09-01"
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "invalid range: --to 2026-09-01 is before --from 2026-09-30")
+
+	flagMonth = "2026-09"
+	flagMonthChange = "end"
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--month-boundary can not be used with --month or --from/--to, the edges of the range are filtered automatically")
+
+	flagMonthChange = ""
+	flagOutput = "cats.tsv"
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), `--output "cats.tsv" needs a {week} placeholder to write one file per week, or use --combine`)
+
+	flagCombine = true
+	assert.NoError(t, cmd.PreRunE(cmd, []string{}))
+
+	flagMonth = ""
+	flagFrom = ""
+	flagTo = ""
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--combine requires --month or --from/--to")
+}
+
 // func TestGenerateCmd_WithDate1JanuarAndFlagCurrent(t *testing.T) {
 // 	m := new(reporterMock)
 
@@ -186,7 +476,142 @@ func TestGenerateCmd_WeekFlag_tooLarge(t *testing.T) {
 
 type reporterMock struct{ mock.Mock }
 
-func (m *reporterMock) Generate(year int, week int, category string, withText bool, monthChange string) (string, float64, error) {
+func (m *reporterMock) Generate(year int, week int, category string, withText bool, monthChange string) (report.Report, error) {
 	args := m.Called(year, week, category, withText, monthChange)
-	return args.String(0), 0, nil
+	if generated, ok := args.Get(0).(report.Report); ok {
+		return generated, args.Error(1)
+	}
+	return report.Report{}, nil
+}
+
+func (m *reporterMock) GenerateRange(from time.Time, to time.Time, category string, withText bool) ([]report.Report, error) {
+	args := m.Called(from, to, category, withText)
+	return args.Get(0).([]report.Report), nil
+}
+
+func TestFormatExcluded(t *testing.T) {
+	locale, err := report.NewLocale("en-US")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", formatExcluded(locale, nil))
+	assert.Equal(t, " (excluded: 0.50h break, 1.25h no-cats)", formatExcluded(locale, map[string]float64{"no-cats": 1.25, "break": 0.5}))
+	assert.Equal(t, " (excluded: 0,50h break)", formatExcluded(report.Locale{}, map[string]float64{"break": 0.5}))
+}
+
+func TestSummaryWriter(t *testing.T) {
+	defer func() {
+		flagFormat = "tsv"
+		flagOutput = ""
+		flagPreview = false
+	}()
+
+	flagFormat = "tsv"
+	assert.Equal(t, os.Stdout, summaryWriter())
+
+	flagFormat = "json"
+	assert.Equal(t, os.Stderr, summaryWriter(), "the json on stdout stays parseable")
+	flagFormat = "ics"
+	assert.Equal(t, os.Stderr, summaryWriter())
+
+	flagOutput = "report.ics"
+	assert.Equal(t, os.Stdout, summaryWriter(), "the report is written to a file")
+	flagOutput = ""
+	flagPreview = true
+	assert.Equal(t, os.Stdout, summaryWriter(), "the preview is printed instead of the report")
+}
+
+func TestLoadDeltaSource(t *testing.T) {
+	store := historyStore
+	historyStore = history.Store{Path: filepath.Join(t.TempDir(), "history.json")}
+	defer func() { historyStore = store }()
+
+	submitted := history.FromReport(makeStatusReport(2*time.Hour), "")
+	submitted.SubmittedAt = time.Date(2026, time.September, 11, 16, 0, 0, 0, time.UTC)
+	_, err := historyStore.Record(submitted)
+	assert.NoError(t, err)
+
+	for _, value := range []string{"1", "#1"} {
+		source, label, err := loadDeltaSource(report.Locale{}, value)
+		assert.NoError(t, err)
+		assert.Equal(t, "submission #1 of 11.09.2026", label)
+		assert.Equal(t, 2.0, source.Total)
+	}
+
+	_, _, err = loadDeltaSource(report.Locale{}, "2")
+	assert.EqualError(t, err, "no submission #2 in the history, see status")
+
+	path := filepath.Join(t.TempDir(), "week.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"year":2026,"week":37,"rows":[]}`), 0o600))
+	source, label, err := loadDeltaSource(report.Locale{}, path)
+	assert.NoError(t, err)
+	assert.Equal(t, path, label)
+	assert.Equal(t, 37, source.Week)
+
+	_, _, err = loadDeltaSource(report.Locale{}, filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "could not read --delta-against")
+}
+
+func TestDeltaReport_grid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "week.json")
+	var submitted strings.Builder
+	assert.NoError(t, report.JSONFormatter{}.Format(&submitted, makeStatusReport(2*time.Hour)))
+	assert.NoError(t, os.WriteFile(path, []byte(submitted.String()), 0o600))
+
+	flagDeltaAgainst = path
+	flagDeltaGrid = true
+	defer func() { flagDeltaAgainst = ""; flagDeltaGrid = false }()
+
+	generated := makeStatusReport(4 * time.Hour)
+	generated.Entries = append(generated.Entries, report.CatsEntity{CatsID: "CATSID-2", Durations: map[string]time.Duration{"2026-09-08": time.Hour}})
+	correction, ok, err := deltaReport(report.Locale{}, generated)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"CATSID-1", "CATSID-2"}, []string{correction.Entries[0].CatsID, correction.Entries[1].CatsID})
+
+	var grid strings.Builder
+	assert.NoError(t, report.TSVFormatter{}.Format(&grid, correction))
+	assert.Equal(t, "CATSID-1\t\t\t\t\tID\t4,00\t\t\nCATSID-2\t\t\t\t\tID\t1,00\t\t\n", grid.String())
+}
+
+func TestDeltaReport_otherWeek(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "week.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"year":2026,"week":36,"rows":[]}`), 0o600))
+
+	flagDeltaAgainst = path
+	defer func() { flagDeltaAgainst = "" }()
+
+	_, _, err := deltaReport(report.Locale{}, makeStatusReport(time.Hour))
+	assert.EqualError(t, err, path+" is week 2026-W36, not 2026-W37")
+}
+
+func TestDeltaReport_otherDays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "week.json")
+	partial := makeStatusReport(2 * time.Hour)
+	partial.Days = nil
+	var submitted strings.Builder
+	assert.NoError(t, report.JSONFormatter{}.Format(&submitted, partial))
+	assert.NoError(t, os.WriteFile(path, []byte(submitted.String()), 0o600))
+
+	flagDeltaAgainst = path
+	defer func() { flagDeltaAgainst = "" }()
+
+	_, _, err := deltaReport(report.Locale{}, makeStatusReport(2*time.Hour))
+	assert.EqualError(t, err, path+" covers other days than the report")
+}
+
+func TestGenerateCmd_DeltaFlags_invalidValues(t *testing.T) {
+	defer func() { flagDeltaAgainst = ""; flagDeltaGrid = false; flagMonth = ""; flagSubmit = false }()
+
+	flagDeltaGrid = true
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--delta-grid requires --delta-against")
+
+	flagDeltaAgainst = "1"
+	flagMonth = "2026-09"
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--delta-against requires a single week")
+
+	flagMonth = ""
+	flagSubmit = true
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--submit can not be used with --delta-against, submit the corrected week without it")
 }
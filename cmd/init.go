@@ -1,8 +1,22 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marvincaspar/clockify2cats/internal/config"
+	fileoutput "github.com/marvincaspar/clockify2cats/internal/output"
+	"github.com/marvincaspar/clockify2cats/internal/report"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 var (
@@ -10,22 +24,243 @@ var (
 	clockifyUserID               string
 	clockifyApiKey               string
 	clockifyDescriptionDelimiter string
+	flagInitForce                bool
+	flagInitSkipVerify           bool
 )
 
-func newInitCmd() *cobra.Command {
+// initClockify is the part of the Clockify API used to verify the credentials, see report.Repository.
+type initClockify interface {
+	FetchClockifyUser() (report.ClockifyUser, error)
+	FetchClockifyWorkspaces() ([]report.ClockifyWorkspace, error)
+}
+
+// initOptions holds the environment of init, so that the wizard can be tested.
+type initOptions struct {
+	ConfigFile  string
+	Interactive bool
+	In          io.Reader
+	Out         io.Writer
+	// ReadSecret reads a line without echoing it.
+	ReadSecret func() (string, error)
+	Clockify   func(apiKey string) initClockify
+}
+
+func newInitCmd(options initOptions) *cobra.Command {
 	return &cobra.Command{
 		Use:   "init",
 		Short: "Initialize clockify2cats config",
-		Long:  `Initialize clockify2cats config by providing workspace ID, user ID and api key.`,
+		Long: `Initialize clockify2cats config by providing workspace ID, user ID and api key.
+Missing values are prompted for in a terminal, the credentials are verified against the Clockify API before the config is saved.
+With --profile, the values are saved as a named profile, e.g. for a second workspace.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			viper.WriteConfig()
-			viper.SafeWriteConfig()
+			if err := runInit(options); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
 		},
 	}
 }
 
+// runInit prompts for the missing values, verifies them and writes the config file.
+func runInit(options initOptions) error {
+	in := bufio.NewReader(options.In)
+	out := options.Out
+	fmt.Fprintf(out, "Config file: %s\n", options.ConfigFile)
+
+	file := config.File{Path: options.ConfigFile}
+	c, err := file.Load()
+	if err != nil {
+		return err
+	}
+
+	// a named profile is added to the config file, only the profile itself needs the confirmation
+	existing, label := "", ""
+	if flagProfile != "" && flagProfile != fileoutput.DefaultProfile {
+		fmt.Fprintf(out, "Profile: %s\n", flagProfile)
+		if _, ok := c.Profile(flagProfile, false); ok {
+			existing, label = "profile "+flagProfile, "The profile "+flagProfile
+		}
+	} else if _, err := os.Stat(options.ConfigFile); err == nil {
+		existing, label = options.ConfigFile, "The config file"
+	}
+	if existing != "" && !flagInitForce {
+		if !options.Interactive {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", existing)
+		}
+		answer, err := prompt(in, out, label+" already exists. Overwrite it? [y/N] ")
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			return errors.New("aborted, the config file was not changed")
+		}
+	}
+
+	workspaceID, userID, apiKey := clockifyWorkspaceID, clockifyUserID, clockifyApiKey
+	if !options.Interactive {
+		missing := []string{}
+		for flag, value := range map[string]string{"--workspace": workspaceID, "--user": userID, "--api-key": apiKey} {
+			if value == "" {
+				missing = append(missing, flag)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return fmt.Errorf("missing %s: pass the flags or run init in a terminal", strings.Join(missing, ", "))
+		}
+	}
+
+	if apiKey == "" {
+		fmt.Fprint(out, "Clockify api key (input hidden): ")
+		secret, err := options.ReadSecret()
+		fmt.Fprintln(out)
+		if err != nil {
+			return err
+		}
+		apiKey = strings.TrimSpace(secret)
+		if apiKey == "" {
+			return errors.New("the api key is required, generate one in the Clockify profile settings")
+		}
+	}
+
+	var owner report.ClockifyUser
+	var workspaces []report.ClockifyWorkspace
+	if !flagInitSkipVerify {
+		clockify := options.Clockify(apiKey)
+
+		var err error
+		owner, err = clockify.FetchClockifyUser()
+		var statusError report.StatusError
+		if errors.As(err, &statusError) && (statusError.StatusCode == http.StatusUnauthorized || statusError.StatusCode == http.StatusForbidden) {
+			return fmt.Errorf("the api key was rejected (%s)", statusError.Status)
+		}
+		if err != nil {
+			return fmt.Errorf("could not verify the api key: %w, use --skip-verify to save the config anyway", err)
+		}
+		fmt.Fprintf(out, "Authenticated as %s <%s>\n", owner.Name, owner.Email)
+
+		workspaces, err = clockify.FetchClockifyWorkspaces()
+		if err != nil {
+			return fmt.Errorf("could not fetch the workspaces: %w", err)
+		}
+	}
+
+	if workspaceID == "" {
+		var err error
+		workspaceID, err = promptWorkspace(in, out, workspaces, owner.ActiveWorkspace)
+		if err != nil {
+			return err
+		}
+	}
+	if !flagInitSkipVerify {
+		if _, ok := report.FindWorkspace(workspaces, workspaceID); !ok {
+			return fmt.Errorf("%s is not a workspace of %s", workspaceID, owner.Email)
+		}
+	}
+
+	if userID == "" {
+		label := "Clockify user ID: "
+		if owner.ID != "" {
+			label = fmt.Sprintf("Clockify user ID [%s]: ", owner.ID)
+		}
+		answer, err := prompt(in, out, label)
+		if err != nil {
+			return err
+		}
+		userID = answer
+		if userID == "" {
+			userID = owner.ID
+		}
+		if userID == "" {
+			return errors.New("the user ID is required")
+		}
+	}
+	if !flagInitSkipVerify && userID != owner.ID {
+		fmt.Fprintf(out, "Warning: %s is not the owner of the api key (%s), reading the entries of other users requires admin permissions\n", userID, owner.ID)
+	}
+
+	settings, _ := c.Profile(flagProfile, true)
+	settings["workspace-id"] = workspaceID
+	settings["user-id"] = userID
+	settings["api-key"] = apiKey
+	settings["description-delimiter"] = clockifyDescriptionDelimiter
+
+	if err := os.MkdirAll(filepath.Dir(options.ConfigFile), 0o700); err != nil {
+		return fmt.Errorf("could not create the config directory: %w", err)
+	}
+	// the config contains the api key, so it is written with permissions 0600
+	if err := file.Save(c); err != nil {
+		return fmt.Errorf("could not write the config file: %w", err)
+	}
+	fmt.Fprintf(out, "Config written to %s\n", options.ConfigFile)
+
+	return nil
+}
+
+// promptWorkspace lists the workspaces and asks for a number or ID, the active workspace is the default.
+func promptWorkspace(in *bufio.Reader, out io.Writer, workspaces []report.ClockifyWorkspace, active string) (string, error) {
+	if len(workspaces) == 0 {
+		answer, err := prompt(in, out, "Clockify workspace ID: ")
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			return "", errors.New("the workspace ID is required")
+		}
+		return answer, nil
+	}
+
+	fmt.Fprintln(out, "Workspaces:")
+	defaultChoice := 1
+	for i, workspace := range workspaces {
+		fmt.Fprintf(out, "  %d) %s (%s)\n", i+1, workspace.Name, workspace.ID)
+		if workspace.ID == active {
+			defaultChoice = i + 1
+		}
+	}
+
+	answer, err := prompt(in, out, fmt.Sprintf("Workspace [%d]: ", defaultChoice))
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return workspaces[defaultChoice-1].ID, nil
+	}
+	if choice, err := strconv.Atoi(answer); err == nil {
+		if choice < 1 || choice > len(workspaces) {
+			return "", fmt.Errorf("invalid choice %d: must be between 1 and %d", choice, len(workspaces))
+		}
+		return workspaces[choice-1].ID, nil
+	}
+
+	return answer, nil
+}
+
+// prompt prints the label and returns the trimmed answer.
+func prompt(in *bufio.Reader, out io.Writer, label string) (string, error) {
+	fmt.Fprint(out, label)
+	answer, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", fmt.Errorf("no answer for %q", strings.TrimSpace(label))
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
 func init() {
-	initCmd := newInitCmd()
+	initCmd := newInitCmd(initOptions{
+		ConfigFile:  configFile(),
+		Interactive: term.IsTerminal(int(os.Stdin.Fd())),
+		In:          os.Stdin,
+		Out:         os.Stdout,
+		ReadSecret: func() (string, error) {
+			secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+			return string(secret), err
+		},
+		Clockify: func(apiKey string) initClockify {
+			return report.Repository{ApiKey: apiKey}
+		},
+	})
 	rootCmd.AddCommand(initCmd)
 
 	// Here you will define your flags and configuration settings.
@@ -33,21 +268,12 @@ func init() {
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// generateCmd.PersistentFlags().String("foo", "", "A help for foo")
-	initCmd.PersistentFlags().StringVar(&clockifyWorkspaceID, "workspace", "", "Clockify workspace ID")
-	initCmd.MarkPersistentFlagRequired("workspace")
-
-	initCmd.PersistentFlags().StringVar(&clockifyUserID, "user", "", "Clockify user ID")
-	initCmd.MarkPersistentFlagRequired("user")
-
-	initCmd.PersistentFlags().StringVar(&clockifyApiKey, "api-key", "", "Clockify api key")
-	initCmd.MarkPersistentFlagRequired("api-key")
-
+	initCmd.PersistentFlags().StringVar(&clockifyWorkspaceID, "workspace", "", "Clockify workspace ID, prompted for if missing")
+	initCmd.PersistentFlags().StringVar(&clockifyUserID, "user", "", "Clockify user ID, prompted for if missing")
+	initCmd.PersistentFlags().StringVar(&clockifyApiKey, "api-key", "", "Clockify api key, prompted for without echo if missing")
 	initCmd.PersistentFlags().StringVar(&clockifyDescriptionDelimiter, "description-delimiter", "#", "Clockify description delimiter to split description into text, text 2 and text external")
-
-	viper.BindPFlag("workspace-id", initCmd.PersistentFlags().Lookup("workspace"))
-	viper.BindPFlag("user-id", initCmd.PersistentFlags().Lookup("user"))
-	viper.BindPFlag("api-key", initCmd.PersistentFlags().Lookup("api-key"))
-	viper.BindPFlag("description-delimiter", initCmd.PersistentFlags().Lookup("description-delimiter"))
+	initCmd.Flags().BoolVar(&flagInitForce, "force", false, "Overwrite an existing config file without confirmation")
+	initCmd.Flags().BoolVar(&flagInitSkipVerify, "skip-verify", false, "Save the config without verifying the credentials against the Clockify API")
 
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
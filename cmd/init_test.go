@@ -1,13 +1,220 @@
 package cmd
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/marvincaspar/clockify2cats/internal/report"
 	"github.com/stretchr/testify/assert"
 )
 
+type initClockifyMock struct {
+	user       report.ClockifyUser
+	workspaces []report.ClockifyWorkspace
+	err        error
+}
+
+func (m initClockifyMock) FetchClockifyUser() (report.ClockifyUser, error) {
+	return m.user, m.err
+}
+
+func (m initClockifyMock) FetchClockifyWorkspaces() ([]report.ClockifyWorkspace, error) {
+	return m.workspaces, nil
+}
+
+// withInitFlags sets the flags of init and returns a function restoring the previous values.
+func withInitFlags(workspaceID, userID, apiKey string, force, skipVerify bool) func() {
+	previous := []interface{}{clockifyWorkspaceID, clockifyUserID, clockifyApiKey, flagInitForce, flagInitSkipVerify}
+	clockifyWorkspaceID, clockifyUserID, clockifyApiKey, flagInitForce, flagInitSkipVerify = workspaceID, userID, apiKey, force, skipVerify
+
+	return func() {
+		clockifyWorkspaceID = previous[0].(string)
+		clockifyUserID = previous[1].(string)
+		clockifyApiKey = previous[2].(string)
+		flagInitForce = previous[3].(bool)
+		flagInitSkipVerify = previous[4].(bool)
+	}
+}
+
+func testInitOptions(configFile string, interactive bool, input string, out *bytes.Buffer) initOptions {
+	return initOptions{
+		ConfigFile:  configFile,
+		Interactive: interactive,
+		In:          strings.NewReader(input),
+		Out:         out,
+		ReadSecret: func() (string, error) {
+			return "secret-key", nil
+		},
+		Clockify: func(apiKey string) initClockify {
+			return initClockifyMock{
+				user:       report.ClockifyUser{ID: "user-1", Name: "Jane Doe", Email: "jane@example.com", ActiveWorkspace: "ws-1"},
+				workspaces: []report.ClockifyWorkspace{{ID: "ws-1", Name: "ACME"}, {ID: "ws-2", Name: "Side project"}},
+			}
+		},
+	}
+}
+
 func TestInitCmd(t *testing.T) {
-	cmd := newInitCmd()
+	defer withInitFlags("ws-1", "user-1", "key", false, true)()
+	configFile := filepath.Join(t.TempDir(), "clockify2cats", "config.yaml")
+
+	out := &bytes.Buffer{}
+	cmd := newInitCmd(testInitOptions(configFile, false, "", out))
 	cmd.Run(cmd, []string{})
 	assert.NotNil(t, cmd)
+
+	data, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "workspace-id: ws-1")
+	assert.Contains(t, string(data), "api-key: key")
+
+	info, err := os.Stat(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.Contains(t, out.String(), "Config written to "+configFile)
+}
+
+func TestRunInit_prompts(t *testing.T) {
+	defer withInitFlags("", "", "", false, false)()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+
+	out := &bytes.Buffer{}
+	err := runInit(testInitOptions(configFile, true, "2\n\n", out))
+	assert.NoError(t, err)
+
+	assert.Contains(t, out.String(), "Config file: "+configFile)
+	assert.Contains(t, out.String(), "Authenticated as Jane Doe <jane@example.com>")
+	assert.Contains(t, out.String(), "  2) Side project (ws-2)\nWorkspace [1]: ")
+	assert.Contains(t, out.String(), "Clockify user ID [user-1]: ")
+	assert.NotContains(t, out.String(), "secret-key")
+
+	data, _ := os.ReadFile(configFile)
+	assert.Contains(t, string(data), "workspace-id: ws-2")
+	assert.Contains(t, string(data), "user-id: user-1")
+	assert.Contains(t, string(data), "api-key: secret-key")
+}
+
+func TestRunInit_otherUser(t *testing.T) {
+	defer withInitFlags("ws-1", "user-2", "key", false, false)()
+
+	out := &bytes.Buffer{}
+	err := runInit(testInitOptions(filepath.Join(t.TempDir(), "config.yaml"), false, "", out))
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Warning: user-2 is not the owner of the api key (user-1)")
+}
+
+func TestRunInit_existingConfig(t *testing.T) {
+	defer withInitFlags("ws-1", "user-1", "key", false, false)()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(configFile, []byte("workspace-id: old\n"), 0o600)
+
+	err := runInit(testInitOptions(configFile, false, "", &bytes.Buffer{}))
+	assert.EqualError(t, err, configFile+" already exists, use --force to overwrite it")
+
+	err = runInit(testInitOptions(configFile, true, "n\n", &bytes.Buffer{}))
+	assert.EqualError(t, err, "aborted, the config file was not changed")
+
+	data, _ := os.ReadFile(configFile)
+	assert.Equal(t, "workspace-id: old\n", string(data))
+
+	err = runInit(testInitOptions(configFile, true, "y\n", &bytes.Buffer{}))
+	assert.NoError(t, err)
+
+	flagInitForce = true
+	os.WriteFile(configFile, []byte("workspace-id: old\n"), 0o600)
+	err = runInit(testInitOptions(configFile, false, "", &bytes.Buffer{}))
+	assert.NoError(t, err)
+	data, _ = os.ReadFile(configFile)
+	assert.Contains(t, string(data), "workspace-id: ws-1")
+}
+
+func TestRunInit_invalid(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+
+	tests := []struct {
+		name        string
+		workspaceID string
+		userID      string
+		apiKey      string
+		interactive bool
+		input       string
+		clockify    initClockify
+		expected    string
+	}{
+		{
+			name:     "missing flags",
+			apiKey:   "key",
+			expected: "missing --user, --workspace: pass the flags or run init in a terminal",
+		},
+		{
+			name:        "rejected api key",
+			workspaceID: "ws-1",
+			userID:      "user-1",
+			apiKey:      "key",
+			clockify:    initClockifyMock{err: report.StatusError{StatusCode: 401, Status: "401 Unauthorized"}},
+			expected:    "the api key was rejected (401 Unauthorized)",
+		},
+		{
+			name:        "unknown workspace",
+			workspaceID: "ws-3",
+			userID:      "user-1",
+			apiKey:      "key",
+			expected:    "ws-3 is not a workspace of jane@example.com",
+		},
+		{
+			name:        "invalid choice",
+			interactive: true,
+			input:       "3\n",
+			expected:    "invalid choice 3: must be between 1 and 2",
+		},
+		{
+			name:        "no answer",
+			interactive: true,
+			expected:    `no answer for "Workspace [1]:"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer withInitFlags(tt.workspaceID, tt.userID, tt.apiKey, false, false)()
+
+			options := testInitOptions(configFile, tt.interactive, tt.input, &bytes.Buffer{})
+			if tt.clockify != nil {
+				options.Clockify = func(apiKey string) initClockify {
+					return tt.clockify
+				}
+			}
+
+			err := runInit(options)
+			assert.EqualError(t, err, tt.expected)
+			_, err = os.Stat(configFile)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestRunInit_profile(t *testing.T) {
+	defer withInitFlags("ws-1", "user-1", "key-b", false, false)()
+	previous := flagProfile
+	defer func() { flagProfile = previous }()
+	flagProfile = "client-b"
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(configFile, []byte("workspace-id: ws-0\napi-key: key-a\n"), 0o600)
+
+	out := &bytes.Buffer{}
+	err := runInit(testInitOptions(configFile, false, "", out))
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Profile: client-b\n")
+
+	data, _ := os.ReadFile(configFile)
+	assert.Contains(t, string(data), "api-key: key-a\n")
+	assert.Contains(t, string(data), "profiles:\n    client-b:\n")
+	assert.Contains(t, string(data), "        api-key: key-b\n")
+
+	err = runInit(testInitOptions(configFile, false, "", &bytes.Buffer{}))
+	assert.EqualError(t, err, "profile client-b already exists, use --force to overwrite it")
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var flagLintFormat string
+
+func newLintCmd(repository report.ProjectRepositoryInterface) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Check the CATS IDs in the Clockify project names",
+		Long: `Fetch all projects of the workspace and check their names like the report does: missing or malformed CATS IDs, unbalanced parentheses, duplicate IDs and "*" markers mixed with CATS IDs.
+Every issue is printed as tab-separated line "severity, code, project, message" or as JSON with --format json. The command exits with status 1 if there are errors.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flagLintFormat != "" && flagLintFormat != "text" && flagLintFormat != "json" {
+				return fmt.Errorf("invalid value %q for --format: must be \"text\" or \"json\"", flagLintFormat)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			projects, err := repository.FetchClockifyProjects()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+
+			issues := report.LintProjects(projects)
+			if err := writeLintIssues(cmd.OutOrStdout(), flagLintFormat, len(projects), issues); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+
+			errors, warnings := countLintIssues(issues)
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s and %s in %s\n", plural(errors, "error"), plural(warnings, "warning"), plural(len(projects), "project"))
+			if errors > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// writeLintIssues writes one tab-separated line per issue or a JSON document with the number of projects and the issues.
+func writeLintIssues(w io.Writer, format string, projects int, issues []report.LintIssue) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Projects int                `json:"projects"`
+			Issues   []report.LintIssue `json:"issues"`
+		}{projects, issues})
+	}
+
+	for _, issue := range issues {
+		// tabs and line breaks would break the columns
+		project := strings.NewReplacer("\t", " ", "\n", " ").Replace(issue.Project)
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Severity, issue.Code, project, issue.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func countLintIssues(issues []report.LintIssue) (int, int) {
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		if issue.Severity == report.LintError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	return errors, warnings
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func init() {
+	lintCmd := newLintCmd(&clockifyRepository)
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVarP(&flagLintFormat, "format", "f", "text", `Output format: "text" (tab-separated) or "json"`)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type projectRepositoryMock struct {
+	projects []report.ClockifyProject
+}
+
+func (m projectRepositoryMock) FetchClockifyProjects() ([]report.ClockifyProject, error) {
+	return m.projects, nil
+}
+
+func TestLintCmd_noErrors(t *testing.T) {
+	cmd := newLintCmd(projectRepositoryMock{projects: []report.ClockifyProject{
+		{ID: "1", Name: "Project (CATSID-1)"},
+		{ID: "2", Name: "Other (CATSID-1)"},
+	}})
+	flagLintFormat = "text"
+	defer func() { flagLintFormat = "" }()
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.Run(cmd, []string{})
+
+	assert.Equal(t, "warning\tduplicate-id\tProject (CATSID-1)\tCATS ID \"CATSID-1\" is also used by project \"Other (CATSID-1)\"\n", stdout.String())
+	assert.Equal(t, "0 errors and 1 warning in 2 projects\n", stderr.String())
+}
+
+func TestLintCmd_FormatFlag_invalidValue(t *testing.T) {
+	cmd := newLintCmd(projectRepositoryMock{})
+	flagLintFormat = "yaml"
+	defer func() { flagLintFormat = "" }()
+
+	err := cmd.PreRunE(cmd, []string{})
+	assert.EqualError(t, err, `invalid value "yaml" for --format: must be "text" or "json"`)
+}
+
+func TestWriteLintIssues_json(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := writeLintIssues(buf, "json", 3, []report.LintIssue{
+		{ProjectID: "1", Project: "Internal", Severity: report.LintError, Code: "missing-id", Message: "no CATS ID"},
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"projects":3,"issues":[{"projectId":"1","project":"Internal","severity":"error","code":"missing-id","message":"no CATS ID"}]}`, buf.String())
+}
+
+func TestWriteLintIssues_jsonWithoutIssues(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := writeLintIssues(buf, "json", 0, []report.LintIssue{})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"projects":0,"issues":[]}`, buf.String())
+}
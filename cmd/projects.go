@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagProjectsFormat   string
+	flagProjectsFilter   string
+	flagProjectsUnmapped bool
+)
+
+func newProjectsCmd(repository report.ProjectRepositoryInterface) *cobra.Command {
+	return &cobra.Command{
+		Use:   "projects",
+		Short: "List the Clockify projects with their CATS IDs",
+		Long:  `List the projects of the workspace with their client, billable default, the CATS IDs and weights resolved from the project name and whether their time is distributed to (*) or receives shared time (billable).`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flagProjectsFormat != "" && flagProjectsFormat != "text" && flagProjectsFormat != "json" {
+				return fmt.Errorf("invalid value %q for --format: must be \"text\" or \"json\"", flagProjectsFormat)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			projects, err := repository.FetchClockifyProjects()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+
+			mappings := filterProjects(report.MapProjects(projects), flagProjectsFilter, flagProjectsUnmapped)
+			if err := writeProjects(cmd.OutOrStdout(), flagProjectsFormat, mappings); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// filterProjects keeps the projects whose name, client or CATS IDs contain the filter, case-insensitively.
+func filterProjects(mappings []report.ProjectMapping, filter string, unmapped bool) []report.ProjectMapping {
+	filter = strings.ToLower(filter)
+
+	filtered := []report.ProjectMapping{}
+	for _, mapping := range mappings {
+		if unmapped && mapping.Mapped() {
+			continue
+		}
+
+		text := strings.ToLower(strings.Join(append([]string{mapping.Project, mapping.Client}, mapping.CatsIDs...), "\n"))
+		if strings.Contains(text, filter) {
+			filtered = append(filtered, mapping)
+		}
+	}
+
+	return filtered
+}
+
+// writeProjects writes the projects as aligned table or as JSON array.
+func writeProjects(w io.Writer, format string, mappings []report.ProjectMapping) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(mappings)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "PROJECT\tCLIENT\tBILLABLE\tCATS IDS\tSHARED\tSOURCE")
+	for _, mapping := range mappings {
+		billable := "no"
+		if mapping.Billable {
+			billable = "yes"
+		}
+
+		catsIDs := make([]string, len(mapping.CatsIDs))
+		for i, catsID := range mapping.CatsIDs {
+			catsIDs[i] = catsID
+			if len(mapping.Weights) > 1 {
+				catsIDs[i] += fmt.Sprintf(" (%.0f%%)", mapping.Weights[i]*100)
+			}
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", mapping.Project, valueOrDash(mapping.Client), billable, strings.Join(catsIDs, ", "), valueOrDash(mapping.Shared), mapping.Source)
+	}
+
+	return table.Flush()
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func init() {
+	projectsCmd := newProjectsCmd(&clockifyRepository)
+	rootCmd.AddCommand(projectsCmd)
+
+	projectsCmd.Flags().StringVarP(&flagProjectsFormat, "format", "f", "text", `Output format: "text" (table) or "json"`)
+	projectsCmd.Flags().StringVar(&flagProjectsFilter, "filter", "", "Only list projects whose name, client or CATS IDs contain the text")
+	projectsCmd.Flags().BoolVar(&flagProjectsUnmapped, "unmapped", false, "Only list projects without CATS ID")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestProjects() []report.ClockifyProject {
+	return []report.ClockifyProject{
+		{ID: "1", Name: "Portal (CATSID-1, CATSID-2)", ClientName: "ACME", Billable: true},
+		{ID: "2", Name: "Meetings (*)"},
+		{ID: "3", Name: "Internal"},
+	}
+}
+
+func TestProjectsCmd_text(t *testing.T) {
+	cmd := newProjectsCmd(projectRepositoryMock{projects: makeTestProjects()})
+	flagProjectsFormat = "text"
+	defer func() { flagProjectsFormat = "" }()
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.Run(cmd, []string{})
+
+	assert.Equal(t, "PROJECT                      CLIENT  BILLABLE  CATS IDS                        SHARED       SOURCE\n"+
+		"Internal                     -       no        -                               -            name\n"+
+		"Meetings (*)                 -       no        *                               distributed  name\n"+
+		"Portal (CATSID-1, CATSID-2)  ACME    yes       CATSID-1 (50%), CATSID-2 (50%)  receiving    name\n", buf.String())
+}
+
+func TestProjectsCmd_json(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := writeProjects(buf, "json", report.MapProjects(makeTestProjects()[:1]))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"projectId":"1","project":"Portal (CATSID-1, CATSID-2)","client":"ACME","billable":true,"catsIds":["CATSID-1","CATSID-2"],"weights":[0.5,0.5],"shared":"receiving","source":"name"}]`, buf.String())
+}
+
+func TestFilterProjects(t *testing.T) {
+	mappings := report.MapProjects(makeTestProjects())
+
+	assert.Len(t, filterProjects(mappings, "", false), 3)
+	assert.Equal(t, "Portal (CATSID-1, CATSID-2)", filterProjects(mappings, "acme", false)[0].Project)
+	assert.Equal(t, "Portal (CATSID-1, CATSID-2)", filterProjects(mappings, "catsid-2", false)[0].Project)
+	assert.Equal(t, "Internal", filterProjects(mappings, "", true)[0].Project)
+	assert.Len(t, filterProjects(mappings, "acme", true), 0)
+}
+
+func TestProjectsCmd_FormatFlag_invalidValue(t *testing.T) {
+	cmd := newProjectsCmd(projectRepositoryMock{})
+	flagProjectsFormat = "yaml"
+	defer func() { flagProjectsFormat = "" }()
+
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), `invalid value "yaml" for --format: must be "text" or "json"`)
+}
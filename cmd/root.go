@@ -1,18 +1,44 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/marvincaspar/clockify2cats/internal/config"
+	fileoutput "github.com/marvincaspar/clockify2cats/internal/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
-var cfgFile string
+// profileEnv selects a profile like --profile.
+const profileEnv = "CLOCKIFY2CATS_PROFILE"
+
+var (
+	cfgFile string
+	// flagProfile is the name of the selected profile, empty for the top level of the config file.
+	flagProfile string
+	// configHooks configure the commands with the settings of the selected profile. They run once the flags are
+	// parsed, as the profile is only known then.
+	configHooks []func()
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "clockify2cats",
 	Short: "Tool to convert clockify time entries to cats time entries",
 	Long:  `This tool allows you to convert clockify time entries to cats time entries.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initConfig()
+		if err := selectProfile(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		for _, hook := range configHooks {
+			hook()
+		}
+	},
 }
 
 func Execute() {
@@ -23,14 +49,34 @@ func Execute() {
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().StringVar(&flagProfile, "profile", os.Getenv(profileEnv), "Profile of the config file to use, defaults to $"+profileEnv)
+}
+
+// configDir returns the platform-specific directory for the config file and local data.
+func configDir() string {
+	dir, err := os.UserConfigDir()
+	cobra.CheckErr(err)
+
+	return dir + string(os.PathSeparator) + "clockify2cats"
+}
+
+func configFile() string {
+	return filepath.Join(configDir(), "config.yaml")
+}
+
+// dataDir returns the directory of the local data of the selected profile, e.g. the submission history.
+// Named profiles keep their data apart, as they report to different CATS systems.
+func dataDir() string {
+	if flagProfile == "" || flagProfile == fileoutput.DefaultProfile {
+		return configDir()
+	}
+
+	return filepath.Join(configDir(), "profiles", flagProfile)
 }
 
 func initConfig() {
 	// Find config directory.
-	configDir, err := os.UserConfigDir()
-	cobra.CheckErr(err)
-	configDir += string(os.PathSeparator) + "clockify2cats"
+	configDir := configDir()
 
 	os.MkdirAll(configDir, os.ModePerm)
 
@@ -43,3 +89,31 @@ func initConfig() {
 
 	viper.ReadInConfig()
 }
+
+// selectProfile replaces the settings read by viper with the settings of the selected profile. init and config
+// create profiles, so a missing profile is only an error for the other commands.
+func selectProfile(cmd *cobra.Command) error {
+	if flagProfile == "" || flagProfile == fileoutput.DefaultProfile {
+		return nil
+	}
+
+	c, err := config.File{Path: configFile()}.Load()
+	if err != nil {
+		return err
+	}
+
+	resolved, ok := c.Resolve(flagProfile)
+	if !ok {
+		if cmd.Name() == "init" || (cmd.HasParent() && cmd.Parent().Name() == "config") {
+			return nil
+		}
+		return unknownProfileError(c, flagProfile)
+	}
+
+	data, err := yaml.Marshal(map[string]interface{}(resolved))
+	if err != nil {
+		return err
+	}
+
+	return viper.ReadConfig(bytes.NewReader(data))
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// withProfileConfig writes the config file to a temporary config directory and selects the profile.
+func withProfileConfig(t *testing.T, content string, profile string) func() {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	os.MkdirAll(configDir(), 0o700)
+	os.WriteFile(configFile(), []byte(content), 0o600)
+
+	previous := flagProfile
+	flagProfile = profile
+	initConfig()
+
+	return func() {
+		flagProfile = previous
+		viper.Reset()
+	}
+}
+
+const profileConfig = `workspace-id: ws-1
+api-key: key-1
+format: csv
+profiles:
+  client-b:
+    workspace-id: ws-2
+    api-key: key-2
+    category: ZZ
+`
+
+func TestSelectProfile(t *testing.T) {
+	defer withProfileConfig(t, profileConfig, "client-b")()
+
+	assert.NoError(t, selectProfile(&cobra.Command{Use: "generate"}))
+	assert.Equal(t, "ws-2", viper.GetString("workspace-id"))
+	assert.Equal(t, "key-2", viper.GetString("api-key"))
+	assert.Equal(t, "csv", viper.GetString("format"))
+	assert.Equal(t, "ZZ", viper.GetString("category"))
+	assert.Equal(t, filepath.Join(configDir(), "profiles", "client-b"), dataDir())
+}
+
+func TestSelectProfile_default(t *testing.T) {
+	defer withProfileConfig(t, profileConfig, "")()
+
+	assert.NoError(t, selectProfile(&cobra.Command{Use: "generate"}))
+	assert.Equal(t, "ws-1", viper.GetString("workspace-id"))
+	assert.Equal(t, configDir(), dataDir())
+}
+
+func TestSelectProfile_unknown(t *testing.T) {
+	defer withProfileConfig(t, profileConfig, "client-c")()
+
+	assert.EqualError(t, selectProfile(&cobra.Command{Use: "generate"}), `unknown profile "client-c", must be one of client-b`)
+
+	// init and config create the profile
+	assert.NoError(t, selectProfile(&cobra.Command{Use: "init"}))
+	configCmd := &cobra.Command{Use: "config"}
+	setCmd := &cobra.Command{Use: "set"}
+	configCmd.AddCommand(setCmd)
+	assert.NoError(t, selectProfile(setCmd))
+}
+
+func TestConfigureGenerate_profile(t *testing.T) {
+	defer withProfileConfig(t, profileConfig, "client-b")()
+	previousFormat, previousCategory, previousOutput := flagFormat, flagCategory, flagOutput
+	previousReporter, previousRepository, previousOptions := clockifyReporter, clockifyRepository, formatOptions
+	previousHistory, previousFlexTime := historyStore, flexTimeStore
+	defer func() {
+		flagFormat, flagCategory, flagOutput = previousFormat, previousCategory, previousOutput
+		clockifyReporter, clockifyRepository, formatOptions = previousReporter, previousRepository, previousOptions
+		historyStore, flexTimeStore = previousHistory, previousFlexTime
+	}()
+
+	generateCmd := newGenerateCmd(time.Now(), &clockifyReporter)
+	generateCmd.Flags().StringVar(&flagFormat, "format", "tsv", "")
+	generateCmd.Flags().StringVar(&flagCategory, "category", "ID", "")
+	generateCmd.Flags().Set("category", "AB")
+
+	assert.NoError(t, selectProfile(generateCmd))
+	configureGenerate(generateCmd, time.Now())
+
+	assert.Equal(t, "ws-2", clockifyRepository.WorkspaceID)
+	assert.Equal(t, "key-2", clockifyRepository.ApiKey)
+	assert.Equal(t, "csv", flagFormat)
+	// the flag takes precedence over the profile
+	assert.Equal(t, "AB", flagCategory)
+	assert.Equal(t, filepath.Join(configDir(), "profiles", "client-b", "history.json"), historyStore.Path)
+
+	flagOutput = "cats-{profile}-{week}.tsv"
+	assert.Contains(t, outputPath(makeStatusReport(time.Hour)), "cats-client-b-")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/history"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var flagStatusWeeks int
+
+func newStatusCmd(reporter report.ReporterInterface) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Check submitted weeks for changes in Clockify",
+		Long:  `Generate the weeks recorded with generate --submit again and flag every week whose hours changed in Clockify since it was submitted to CATS. The command exits with status 1 if a week changed and with status 2 if weeks could not be checked, but none changed.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if flagStatusWeeks < 0 {
+				return fmt.Errorf("invalid value %d for --weeks: must be 0 (all) or more", flagStatusWeeks)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			loaded, err := historyStore.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+
+			submissions := loaded.Latest()
+			if len(submissions) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No submissions recorded yet, record a week with generate --submit")
+				return
+			}
+			if flagStatusWeeks > 0 && len(submissions) > flagStatusWeeks {
+				submissions = submissions[:flagStatusWeeks]
+			}
+
+			changed, failed := checkSubmissions(cmd.OutOrStdout(), configuredLocale(), reporter, submissions)
+			if failed > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%s could not be checked, see the errors above\n", plural(failed, "week"))
+			}
+			if changed > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%s changed since the submission, correct them in CATS and submit them again\n", plural(changed, "week"))
+				os.Exit(1)
+			}
+			if failed > 0 {
+				os.Exit(2)
+			}
+		},
+	}
+}
+
+// checkSubmissions generates the submitted weeks again and prints the changed cells.
+// It returns the number of weeks that changed and the number of weeks that could not be generated.
+func checkSubmissions(w io.Writer, locale report.Locale, reporter report.ReporterInterface, submissions []history.Submission) (int, int) {
+	changed, failed := 0, 0
+	for _, submission := range submissions {
+		fmt.Fprintf(w, "%-14s #%-3d submitted %s  ", submission.Key(), submission.ID, locale.FormatDate(submission.SubmittedAt))
+
+		generated, err := reporter.Generate(submission.Year, submission.Week, submission.Category, submission.WithText, submission.MonthBoundary)
+		if err != nil {
+			fmt.Fprintf(w, "could not check: %s\n", err)
+			failed++
+			continue
+		}
+
+		current := history.FromReport(generated, submission.MonthBoundary)
+		changes := history.Compare(submission, current)
+		if len(changes) == 0 {
+			fmt.Fprintln(w, "unchanged")
+			continue
+		}
+
+		changed++
+		fmt.Fprintf(w, "changed: %s → %s\n", locale.FormatHoursWithUnit(submission.Total), locale.FormatHoursWithUnit(current.Total))
+		printChanges(w, locale, changes)
+	}
+
+	return changed, failed
+}
+
+// printChanges prints a line with the old and new hours of every changed cell.
+func printChanges(w io.Writer, locale report.Locale, changes []history.Change) {
+	for _, change := range changes {
+		date, _ := time.Parse("2006-01-02", change.Date)
+		fmt.Fprintf(w, "  %s %s: %s → %s\n", rowLabel(change.Row), locale.FormatDayHeader(date), locale.FormatHours(change.Old), locale.FormatHours(change.New))
+	}
+}
+
+// rowLabel returns the CATS ID of a row followed by its texts.
+func rowLabel(row history.Row) string {
+	parts := []string{row.CatsID}
+	for _, text := range []string{row.Text, row.Text2, row.TextExternal} {
+		if text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+// configuredLocale returns the locale and hours format of the config file, invalid values fall back to the defaults.
+func configuredLocale() report.Locale {
+	locale, err := report.NewLocale(viper.GetString("locale"))
+	if err != nil {
+		locale = report.Locale{}
+	}
+	hoursFormat, err := report.ParseHoursFormat(viper.GetString("hours-format"))
+	if err != nil {
+		hoursFormat = report.HoursDecimal
+	}
+
+	return locale.WithHoursFormat(hoursFormat)
+}
+
+func init() {
+	statusCmd := newStatusCmd(&clockifyReporter)
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().IntVar(&flagStatusWeeks, "weeks", 10, "Number of submitted weeks to check, the most recent first, 0 checks all")
+}
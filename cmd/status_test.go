@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/history"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// makeStatusReport returns week 2026-W37 reduced to Tuesday, 08.09., with one row of the given hours.
+func makeStatusReport(hours time.Duration) report.Report {
+	return report.Report{
+		Year:     2026,
+		Week:     37,
+		Category: "ID",
+		Days:     []time.Time{time.Date(2026, time.September, 8, 0, 0, 0, 0, time.UTC)},
+		Entries:  []report.CatsEntity{{CatsID: "CATSID-1", Durations: map[string]time.Duration{"2026-09-08": hours}}},
+		Summary:  report.Summary{Total: hours.Hours()},
+	}
+}
+
+func TestCheckSubmissions(t *testing.T) {
+	m := new(reporterMock)
+	m.On("Generate", 2026, 37, "ID", false, "").Return(makeStatusReport(4*time.Hour), nil)
+	m.On("Generate", 2026, 36, "ID", false, "start").Return(report.Report{}, errors.New("Clockify API error: 401 Unauthorized"))
+
+	submitted := history.FromReport(makeStatusReport(2*time.Hour), "")
+	submitted.ID = 2
+	submitted.SubmittedAt = time.Date(2026, time.September, 11, 16, 0, 0, 0, time.UTC)
+	unchanged := history.FromReport(makeStatusReport(4*time.Hour), "")
+	unchanged.ID = 3
+	unchanged.SubmittedAt = submitted.SubmittedAt
+	failed := history.Submission{ID: 1, Year: 2026, Week: 36, MonthBoundary: "start", Category: "ID", SubmittedAt: submitted.SubmittedAt}
+
+	buf := new(bytes.Buffer)
+	changed, unchecked := checkSubmissions(buf, report.Locale{}, m, []history.Submission{submitted, unchanged, failed})
+
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, 1, unchecked)
+	assert.Equal(t, "2026-W37       #2   submitted 11.09.2026  changed: 2,00h → 4,00h\n"+
+		"  CATSID-1 Di 08.09.: 2,00 → 4,00\n"+
+		"2026-W37       #3   submitted 11.09.2026  unchanged\n"+
+		"2026-W36/start #1   submitted 11.09.2026  could not check: Clockify API error: 401 Unauthorized\n", buf.String())
+}
+
+func TestCheckSubmissions_fetchError(t *testing.T) {
+	m := new(reporterMock)
+	m.On("Generate", 2026, 37, "ID", false, "").Return(report.Report{}, errors.New("Clockify API error: 503 Service Unavailable"))
+
+	submitted := history.FromReport(makeStatusReport(2*time.Hour), "")
+	submitted.ID = 1
+	submitted.SubmittedAt = time.Date(2026, time.September, 11, 16, 0, 0, 0, time.UTC)
+
+	buf := new(bytes.Buffer)
+	changed, failed := checkSubmissions(buf, report.Locale{}, m, []history.Submission{submitted})
+
+	assert.Equal(t, 0, changed, "a failed fetch is no drift")
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, "2026-W37       #1   submitted 11.09.2026  could not check: Clockify API error: 503 Service Unavailable\n", buf.String())
+}
+
+func TestStatusCmd_noSubmissions(t *testing.T) {
+	store := historyStore
+	historyStore = history.Store{Path: filepath.Join(t.TempDir(), "history.json")}
+	defer func() { historyStore = store }()
+
+	cmd := newStatusCmd(new(reporterMock))
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.Run(cmd, []string{})
+
+	assert.Equal(t, "No submissions recorded yet, record a week with generate --submit\n", buf.String())
+}
+
+func TestStatusCmd_WeeksFlag_invalidValue(t *testing.T) {
+	weeks := flagStatusWeeks
+	flagStatusWeeks = -1
+	defer func() { flagStatusWeeks = weeks }()
+
+	cmd := newStatusCmd(new(reporterMock))
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "invalid value -1 for --weeks: must be 0 (all) or more")
+}
+
+func TestGenerateCmd_WithSubmit_recordsSubmission(t *testing.T) {
+	store := historyStore
+	historyStore = history.Store{Path: filepath.Join(t.TempDir(), "history.json")}
+	defer func() { historyStore = store }()
+
+	monday := time.Date(2026, time.September, 7, 0, 0, 0, 0, time.UTC)
+	generated := makeStatusReport(2 * time.Hour)
+	generated.Period = report.Period{From: monday, To: monday.AddDate(0, 0, 6)}
+	m := new(reporterMock)
+	m.On("Generate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(generated, nil).Once()
+
+	flagCurrentWeek = true
+	flagSubmit = true
+	defer func() { flagCurrentWeek = false; flagSubmit = false }()
+	testTime := time.Date(2026, time.September, 11, 16, 0, 0, 0, time.UTC)
+	cmd := newGenerateCmd(testTime, m)
+	cmd.Run(cmd, []string{})
+
+	loaded, err := historyStore.Load()
+	assert.NoError(t, err)
+	assert.Len(t, loaded.Submissions, 1)
+	assert.Equal(t, "2026-W37", loaded.Submissions[0].Key())
+	assert.Equal(t, testTime, loaded.Submissions[0].SubmittedAt)
+	assert.Equal(t, 2.0, loaded.Submissions[0].Total)
+}
+
+func TestGenerateCmd_SubmitFlag_withCombine(t *testing.T) {
+	flagMonth = "2026-09"
+	flagCombine = true
+	flagSubmit = true
+	defer func() { flagMonth = ""; flagCombine = false; flagSubmit = false }()
+
+	cmd := newGenerateCmd(time.Now(), &reporterMock{})
+	assert.EqualError(t, cmd.PreRunE(cmd, []string{}), "--submit can not be used with --combine, submit the weeks one by one")
+}
@@ -0,0 +1,465 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marvincaspar/clockify2cats/internal/holiday"
+	"github.com/marvincaspar/clockify2cats/internal/output"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"gopkg.in/yaml.v3"
+)
+
+type Type string
+
+const (
+	String Type = "string"
+	Bool   Type = "bool"
+	Float  Type = "float"
+	// List values are set as comma-separated string, e.g. "meeting, internal".
+	List Type = "list"
+	// Section values are nested structures like target-hours, they can only be changed with config edit.
+	Section Type = "section"
+)
+
+// Key is a setting of the config file. Nested keys are separated by dots, e.g. "holidays.state".
+type Key struct {
+	Name     string
+	Type     Type
+	Secret   bool
+	Validate func(value string) error
+}
+
+// Keys are all settings read by clockify2cats.
+var Keys = []Key{
+	{Name: "workspace-id", Type: String},
+	{Name: "user-id", Type: String},
+	{Name: "api-key", Type: String, Secret: true},
+	{Name: "description-delimiter", Type: String},
+	{Name: "category", Type: String},
+	{Name: "format", Type: String, Validate: validateFormat},
+	{Name: "locale", Type: String, Validate: validateLocale},
+	{Name: "hours-format", Type: String, Validate: validateHoursFormat},
+	{Name: "flex-time-balance", Type: Float, Validate: validateFinite},
+	{Name: "exclude-tags", Type: List},
+	{Name: "holidays.state", Type: String, Validate: validateState},
+	{Name: "holidays.cats-id", Type: String},
+	{Name: "holidays.files", Type: List},
+	{Name: "time-off.policies", Type: Section},
+	{Name: "target-hours", Type: Section},
+	{Name: "breaks", Type: Section},
+	{Name: "template.file", Type: String},
+	{Name: "xlsx.sheet", Type: String},
+	{Name: "xlsx.no-metadata", Type: Bool},
+	{Name: "xlsx.columns", Type: Section},
+	{Name: "records.personnel-number", Type: String},
+	{Name: "records.date-format", Type: String},
+	{Name: "records.fields", Type: List},
+	{Name: "records.separator", Type: String},
+	{Name: "records.header", Type: Bool},
+	{Name: ProfilesKey, Type: Section},
+}
+
+// ProfilesKey holds the named profiles. A profile has the same keys as the top level of the config file.
+const ProfilesKey = "profiles"
+
+// Setting is a value of the config file, formatted for display.
+type Setting struct {
+	Key   string
+	Value string
+	Known bool
+}
+
+// File reads and writes the YAML config file. It is written with permissions 0600, as it contains the api key.
+type File struct {
+	Path string
+}
+
+// Config holds the content of the config file.
+type Config map[string]interface{}
+
+// FindKey returns the known key with the given name.
+func FindKey(name string) (Key, bool) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+
+	return Key{}, false
+}
+
+// Load reads the config file, a missing file is an empty config.
+func (f File) Load() (Config, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	// a plain map, yaml would decode the nested sections as Config otherwise
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("error parsing config file %s: %w", f.Path, err)
+	}
+	if config == nil {
+		return Config{}, nil
+	}
+
+	return Config(config), nil
+}
+
+func (f File) Save(config Config) error {
+	data, err := yaml.Marshal(map[string]interface{}(config))
+	if err != nil {
+		return err
+	}
+	// the api key must never be readable by other users, not even for a moment
+	return output.WriteFile(f.Path, data, 0o600)
+}
+
+// Get returns the value of a key, nested values are returned as map or slice.
+func (c Config) Get(name string) (interface{}, bool) {
+	var value interface{} = map[string]interface{}(c)
+	for _, part := range strings.Split(name, ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok = section[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// Set parses the value according to the type of the key, validates and stores it.
+func (c Config) Set(name string, value string) error {
+	key, ok := FindKey(name)
+	if !ok {
+		return unknownKeyError(name)
+	}
+
+	parsed, err := key.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	section := map[string]interface{}(c)
+	parts := strings.Split(name, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := section[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			section[part] = next
+		}
+		section = next
+	}
+	section[parts[len(parts)-1]] = parsed
+
+	return nil
+}
+
+// Unset removes a key and the sections that become empty. It returns false if the key was not set.
+func (c Config) Unset(name string) bool {
+	return unset(c, strings.Split(name, "."))
+}
+
+func unset(section map[string]interface{}, parts []string) bool {
+	if len(parts) == 1 {
+		_, ok := section[parts[0]]
+		delete(section, parts[0])
+		return ok
+	}
+
+	next, ok := section[parts[0]].(map[string]interface{})
+	if !ok || !unset(next, parts[1:]) {
+		return false
+	}
+	if len(next) == 0 {
+		delete(section, parts[0])
+	}
+
+	return true
+}
+
+// Settings returns all values of the config sorted by key, secrets are redacted. Sections are formatted as JSON,
+// keys that clockify2cats does not read are marked as unknown.
+func (c Config) Settings() []Setting {
+	settings := []Setting{}
+	flatten(c, "", "", &settings)
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+
+	return settings
+}
+
+// flatten appends the settings of the section. The path of a setting starts with the display prefix, the key with
+// the key prefix, so that the keys of profiles are looked up like the keys of the top level.
+func flatten(section map[string]interface{}, prefix string, keyPrefix string, settings *[]Setting) {
+	for name, value := range section {
+		path := prefix + name
+		key, known := FindKey(keyPrefix + name)
+		nested, isSection := value.(map[string]interface{})
+		if prefix == "" && name == ProfilesKey && isSection {
+			for profile, profileValue := range nested {
+				if profileSection, ok := profileValue.(map[string]interface{}); ok {
+					flatten(profileSection, path+"."+profile+".", "", settings)
+				}
+			}
+			continue
+		}
+		if !known && isSection && len(nested) > 0 {
+			flatten(nested, path+".", keyPrefix+name+".", settings)
+			continue
+		}
+
+		formatted := Format(value)
+		if key.Secret {
+			formatted = Redact(formatted)
+		}
+		*settings = append(*settings, Setting{Key: path, Value: formatted, Known: known})
+	}
+}
+
+// Profile returns the settings of a named profile, they are shared with the config. The empty name and
+// output.DefaultProfile return the top level. With create, a missing profile is added.
+func (c Config) Profile(name string, create bool) (Config, bool) {
+	if name == "" || name == output.DefaultProfile {
+		return c, true
+	}
+
+	profiles, ok := c[ProfilesKey].(map[string]interface{})
+	if !ok {
+		if !create {
+			return nil, false
+		}
+		profiles = map[string]interface{}{}
+		c[ProfilesKey] = profiles
+	}
+	profile, ok := profiles[name].(map[string]interface{})
+	if !ok {
+		if !create {
+			return nil, false
+		}
+		profile = map[string]interface{}{}
+		profiles[name] = profile
+	}
+
+	return Config(profile), true
+}
+
+// Profiles returns the names of the named profiles, sorted.
+func (c Config) Profiles() []string {
+	profiles, _ := c[ProfilesKey].(map[string]interface{})
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Resolve returns the settings of a profile: the top level of the config file, where every key set in the profile
+// replaces the key of the top level as a whole, e.g. the time-off policies of a profile are not merged.
+func (c Config) Resolve(name string) (Config, bool) {
+	profile, ok := c.Profile(name, false)
+	if !ok {
+		return nil, false
+	}
+
+	resolved := Config{}
+	for key, value := range c {
+		if key != ProfilesKey {
+			resolved[key] = value
+		}
+	}
+	for key, value := range profile {
+		if key != ProfilesKey {
+			resolved[key] = value
+		}
+	}
+
+	return resolved, true
+}
+
+// Parse converts a value given on the command line to the type of the key.
+func (k Key) Parse(value string) (interface{}, error) {
+	var parsed interface{}
+	switch k.Type {
+	case Section:
+		return nil, fmt.Errorf("%s is a section, change it with config edit", k.Name)
+	case Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: must be true or false", value, k.Name)
+		}
+		parsed = b
+	case Float:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: must be a number like 12.5", value, k.Name)
+		}
+		parsed = f
+	case List:
+		list := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		parsed = list
+	default:
+		parsed = value
+	}
+
+	if k.Validate != nil {
+		if err := k.Validate(value); err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s: %w", value, k.Name, err)
+		}
+	}
+
+	return parsed, nil
+}
+
+// Validate checks the values of the known keys and of the keys of all profiles, e.g. after the file was edited by hand.
+func (c Config) Validate() []error {
+	errs := []error{}
+	for _, key := range Keys {
+		value, ok := c.Get(key.Name)
+		if !ok || value == nil {
+			continue
+		}
+
+		switch key.Type {
+		case Section:
+			continue
+		case List:
+			if _, isList := value.([]interface{}); isList {
+				continue
+			}
+		case Bool:
+			if _, isBool := value.(bool); isBool {
+				continue
+			}
+		case Float:
+			if _, isInt := value.(int); isInt {
+				continue
+			}
+			if f, isFloat := value.(float64); isFloat && !math.IsNaN(f) && !math.IsInf(f, 0) {
+				continue
+			}
+		}
+
+		if _, err := key.Parse(Format(value)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, name := range c.Profiles() {
+		profile, _ := c.Profile(name, false)
+		for _, err := range profile.Validate() {
+			errs = append(errs, fmt.Errorf("profile %s: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+// Format returns scalars as they are written in YAML and lists and sections as JSON.
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool, int, float64:
+		return fmt.Sprint(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// Redact keeps the last four characters of a secret.
+func Redact(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
+
+func unknownKeyError(name string) error {
+	names := make([]string, 0, len(Keys))
+	for _, key := range Keys {
+		if strings.HasPrefix(key.Name, name+".") {
+			names = append(names, key.Name)
+		}
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("%s is a section, use one of %s", name, strings.Join(names, ", "))
+	}
+
+	return fmt.Errorf("unknown key %q, see config list --keys", name)
+}
+
+func validateFormat(value string) error {
+	for _, name := range report.FormatterNames() {
+		if name == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of %s", strings.Join(report.FormatterNames(), ", "))
+}
+
+func validateLocale(value string) error {
+	if _, err := report.NewLocale(value); err != nil {
+		return errors.New(`must be a language tag like "de-DE" or "en-US"`)
+	}
+
+	return nil
+}
+
+func validateHoursFormat(value string) error {
+	if _, err := report.ParseHoursFormat(value); err != nil {
+		return fmt.Errorf("must be one of %s, %s, %s", report.HoursDecimal, report.HoursClock, report.HoursMinutes)
+	}
+
+	return nil
+}
+
+func validateFinite(value string) error {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return errors.New("must be a number like 12.5")
+	}
+
+	return nil
+}
+
+func validateState(value string) error {
+	// the same lookup as generate, which accepts "DE" and lowercase states
+	if _, err := holiday.ForState(value, 2000); err != nil {
+		return fmt.Errorf("must be one of DE, %s", strings.Join(holiday.States(), ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Load_missingFileReturnsEmptyConfig(t *testing.T) {
+	c, err := File{Path: filepath.Join(t.TempDir(), "config.yaml")}.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, Config{}, c)
+}
+
+func TestFile_SaveAndLoad(t *testing.T) {
+	file := File{Path: filepath.Join(t.TempDir(), "clockify2cats", "config.yaml")}
+
+	c := Config{}
+	assert.NoError(t, c.Set("api-key", "secret"))
+	assert.NoError(t, c.Set("holidays.state", "BY"))
+	assert.NoError(t, file.Save(c))
+
+	info, err := os.Stat(file.Path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	data, _ := os.ReadFile(file.Path)
+	assert.Equal(t, "api-key: secret\nholidays:\n    state: BY\n", string(data))
+
+	loaded, err := file.Load()
+	assert.NoError(t, err)
+	value, ok := loaded.Get("holidays.state")
+	assert.True(t, ok)
+	assert.Equal(t, "BY", value)
+}
+
+func TestConfig_Set_states(t *testing.T) {
+	c := Config{}
+	assert.NoError(t, c.Set("holidays.state", "DE"), "nationwide holidays only")
+	assert.NoError(t, c.Set("holidays.state", "by"), "states are case-insensitive like in generate")
+	assert.Empty(t, c.Validate())
+}
+
+func TestConfig_Set_types(t *testing.T) {
+	c := Config{}
+	assert.NoError(t, c.Set("flex-time-balance", "12.5"))
+	assert.NoError(t, c.Set("records.header", "true"))
+	assert.NoError(t, c.Set("exclude-tags", "meeting, internal,"))
+	assert.NoError(t, c.Set("hours-format", "hhmm"))
+
+	assert.Equal(t, Config{
+		"flex-time-balance": 12.5,
+		"records":           map[string]interface{}{"header": true},
+		"exclude-tags":      []interface{}{"meeting", "internal"},
+		"hours-format":      "hhmm",
+	}, c)
+}
+
+func TestConfig_Set_invalid(t *testing.T) {
+	tests := []struct {
+		key      string
+		value    string
+		expected string
+	}{
+		{"workspace", "x", `unknown key "workspace", see config list --keys`},
+		{"records", "x", "records is a section, use one of records.personnel-number, records.date-format, records.fields, records.separator, records.header"},
+		{"target-hours", "x", "target-hours is a section, change it with config edit"},
+		{"flex-time-balance", "12,5", `invalid value "12,5" for flex-time-balance: must be a number like 12.5`},
+		{"flex-time-balance", "NaN", `invalid value "NaN" for flex-time-balance: must be a number like 12.5`},
+		{"flex-time-balance", "-Inf", `invalid value "-Inf" for flex-time-balance: must be a number like 12.5`},
+		{"xlsx.no-metadata", "maybe", `invalid value "maybe" for xlsx.no-metadata: must be true or false`},
+		{"format", "pdf", `invalid value "pdf" for format: must be one of `},
+		{"locale", "!!", `invalid value "!!" for locale: must be a language tag like "de-DE" or "en-US"`},
+		{"hours-format", "hours", `invalid value "hours" for hours-format: must be one of decimal, hhmm, minutes`},
+		{"holidays.state", "XX", `invalid value "XX" for holidays.state: must be one of DE, `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			c := Config{}
+			err := c.Set(tt.key, tt.value)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.Equal(t, Config{}, c)
+		})
+	}
+}
+
+func TestConfig_Unset(t *testing.T) {
+	c := Config{
+		"locale":   "en-US",
+		"holidays": map[string]interface{}{"state": "BY"},
+		"records":  map[string]interface{}{"header": true, "separator": ";"},
+	}
+
+	assert.True(t, c.Unset("holidays.state"))
+	assert.True(t, c.Unset("records.header"))
+	assert.False(t, c.Unset("records.header"))
+	assert.False(t, c.Unset("locale.name"))
+
+	assert.Equal(t, Config{
+		"locale":  "en-US",
+		"records": map[string]interface{}{"separator": ";"},
+	}, c)
+}
+
+func TestConfig_Settings(t *testing.T) {
+	c := Config{
+		"api-key":      "0123456789abcdef",
+		"workspace-id": "ws-1",
+		"exclude-tags": []interface{}{"meeting"},
+		"time-off":     map[string]interface{}{"policies": map[string]interface{}{"Vacation": "URLAUB"}},
+		"holidays":     map[string]interface{}{"stat": "BY"},
+	}
+
+	assert.Equal(t, []Setting{
+		{Key: "api-key", Value: "********cdef", Known: true},
+		{Key: "exclude-tags", Value: `["meeting"]`, Known: true},
+		{Key: "holidays.stat", Value: "BY", Known: false},
+		{Key: "time-off.policies", Value: `{"Vacation":"URLAUB"}`, Known: true},
+		{Key: "workspace-id", Value: "ws-1", Known: true},
+	}, c.Settings())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := Config{
+		"locale":            "en-US",
+		"hours-format":      "hours",
+		"flex-time-balance": 12,
+		"records":           map[string]interface{}{"header": "yes"},
+		"target-hours":      []interface{}{},
+	}
+
+	errs := c.Validate()
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], `invalid value "hours" for hours-format: must be one of decimal, hhmm, minutes`)
+	assert.EqualError(t, errs[1], `invalid value "yes" for records.header: must be true or false`)
+}
+
+func TestConfig_Validate_nonFiniteFlexTime(t *testing.T) {
+	errs := Config{"flex-time-balance": math.Inf(1)}.Validate()
+
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `invalid value "+Inf" for flex-time-balance: must be a number like 12.5`)
+}
+
+func TestRedact(t *testing.T) {
+	assert.Equal(t, "********cdef", Redact("0123456789abcdef"))
+	assert.Equal(t, "***", Redact("abc"))
+}
+
+func TestConfig_Profile(t *testing.T) {
+	c := Config{"locale": "de-DE"}
+
+	top, ok := c.Profile("", false)
+	assert.True(t, ok)
+	assert.Equal(t, c, top)
+	_, ok = c.Profile("client-b", false)
+	assert.False(t, ok)
+
+	profile, ok := c.Profile("client-b", true)
+	assert.True(t, ok)
+	assert.NoError(t, profile.Set("locale", "en-US"))
+	assert.Equal(t, Config{
+		"locale":   "de-DE",
+		"profiles": map[string]interface{}{"client-b": map[string]interface{}{"locale": "en-US"}},
+	}, c)
+	assert.Equal(t, []string{"client-b"}, c.Profiles())
+}
+
+func TestConfig_Resolve(t *testing.T) {
+	c := Config{
+		"workspace-id": "ws-1",
+		"locale":       "de-DE",
+		"time-off":     map[string]interface{}{"policies": map[string]interface{}{"Vacation": "URLAUB", "Sick": "KRANK"}},
+		"profiles": map[string]interface{}{
+			"client-b": map[string]interface{}{
+				"workspace-id": "ws-2",
+				"time-off":     map[string]interface{}{"policies": map[string]interface{}{"Vacation": "0100"}},
+			},
+		},
+	}
+
+	resolved, ok := c.Resolve("client-b")
+	assert.True(t, ok)
+	assert.Equal(t, Config{
+		"workspace-id": "ws-2",
+		"locale":       "de-DE",
+		"time-off":     map[string]interface{}{"policies": map[string]interface{}{"Vacation": "0100"}},
+	}, resolved)
+
+	_, ok = c.Resolve("client-c")
+	assert.False(t, ok)
+}
+
+func TestConfig_Settings_profiles(t *testing.T) {
+	c := Config{
+		"api-key": "0123456789abcdef",
+		"profiles": map[string]interface{}{
+			"client-b": map[string]interface{}{"api-key": "fedcba9876543210", "lokale": "en-US"},
+		},
+	}
+
+	assert.Equal(t, []Setting{
+		{Key: "api-key", Value: "********cdef", Known: true},
+		{Key: "profiles.client-b.api-key", Value: "********3210", Known: true},
+		{Key: "profiles.client-b.lokale", Value: "en-US", Known: false},
+	}, c.Settings())
+}
+
+func TestConfig_Validate_profiles(t *testing.T) {
+	c := Config{
+		"profiles": map[string]interface{}{
+			"client-b": map[string]interface{}{"format": "pdf"},
+		},
+	}
+
+	errs := c.Validate()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), `profile client-b: invalid value "pdf" for format: must be one of `)
+}
@@ -0,0 +1,301 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/config"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+)
+
+type Status string
+
+const (
+	OK      Status = "OK"
+	Warning Status = "WARN"
+	Failed  Status = "FAIL"
+	Skipped Status = "SKIP"
+)
+
+// Check is the result of a single diagnosis, Fix tells the user what to do if the check did not pass.
+type Check struct {
+	Name    string
+	Status  Status
+	Message string
+	Fix     string
+}
+
+// Clockify is the part of the Clockify API used by the checks, see report.Repository.
+type Clockify interface {
+	FetchClockifyUser() (report.ClockifyUser, error)
+	FetchClockifyWorkspaces() ([]report.ClockifyWorkspace, error)
+	FetchClockifyData(start string) ([]report.ClockifyTimeEntry, error)
+}
+
+// Doctor checks the configuration and the environment. The environment is injected, so the checks can be tested.
+type Doctor struct {
+	ConfigFile string
+	// Profile is the selected profile of the config file, empty for the top level.
+	Profile     string
+	WorkspaceID string
+	UserID      string
+	ApiKey      string
+	Clockify    Clockify
+	GOOS        string
+	Getenv      func(key string) string
+	LookPath    func(file string) (string, error)
+	Now         time.Time
+}
+
+// Run runs all checks in order. The API checks are skipped without api key, the workspace and user checks
+// are skipped if the api key is rejected.
+func (d Doctor) Run() []Check {
+	checks := d.configFile()
+	checks = append(checks, d.settings()...)
+
+	user, apiChecks := d.api()
+	checks = append(checks, apiChecks...)
+	checks = append(checks, d.clipboard(), d.timezone(user))
+
+	return checks
+}
+
+// Failures returns the number of failed checks.
+func Failures(checks []Check) int {
+	failures := 0
+	for _, check := range checks {
+		if check.Status == Failed {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+func (d Doctor) configFile() []Check {
+	info, err := os.Stat(d.ConfigFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Check{{
+			Name:    "Config file",
+			Status:  Failed,
+			Message: fmt.Sprintf("%s does not exist", d.ConfigFile),
+			Fix:     "run clockify2cats init --workspace <workspace-id> --user <user-id> --api-key <api-key>",
+		}}
+	}
+	if err != nil {
+		return []Check{{Name: "Config file", Status: Failed, Message: err.Error(), Fix: fmt.Sprintf("check the permissions of %s", d.ConfigFile)}}
+	}
+
+	file, err := os.Open(d.ConfigFile)
+	if err != nil {
+		return []Check{{Name: "Config file", Status: Failed, Message: fmt.Sprintf("%s can not be read: %s", d.ConfigFile, err), Fix: fmt.Sprintf("chmod 600 %s", d.ConfigFile)}}
+	}
+	file.Close()
+
+	checks := []Check{{Name: "Config file", Status: OK, Message: d.ConfigFile}}
+	if d.Profile != "" {
+		checks[0].Message = fmt.Sprintf("%s, profile %s", d.ConfigFile, d.Profile)
+	}
+	// Windows has no permission bits
+	if d.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		checks = append(checks, Check{
+			Name:    "Config permissions",
+			Status:  Warning,
+			Message: fmt.Sprintf("%04o, the api key can be read by other users", info.Mode().Perm()),
+			Fix:     fmt.Sprintf("chmod 600 %s", d.ConfigFile),
+		})
+	} else if d.GOOS != "windows" {
+		checks = append(checks, Check{Name: "Config permissions", Status: OK, Message: fmt.Sprintf("%04o", info.Mode().Perm())})
+	}
+
+	return checks
+}
+
+func (d Doctor) settings() []Check {
+	settings := []struct {
+		key   string
+		value string
+		fix   string
+	}{
+		{"workspace-id", d.WorkspaceID, "the ID is part of the URL of the workspace settings in Clockify"},
+		{"user-id", d.UserID, "doctor prints the ID of the api key owner once the api key is set"},
+		{"api-key", d.ApiKey, "generate one in the Clockify profile settings"},
+	}
+
+	checks := []Check{}
+	for _, setting := range settings {
+		if setting.value == "" {
+			checks = append(checks, Check{
+				Name:    "Setting " + setting.key,
+				Status:  Failed,
+				Message: "not set",
+				Fix:     fmt.Sprintf("run clockify2cats config set %s <value> or clockify2cats init, %s", setting.key, setting.fix),
+			})
+			continue
+		}
+
+		value := setting.value
+		if setting.key == "api-key" {
+			value = config.Redact(value)
+		}
+		checks = append(checks, Check{Name: "Setting " + setting.key, Status: OK, Message: value})
+	}
+
+	return checks
+}
+
+// api checks the reachability of the API, the api key, the workspace and the access to the time entries of the user.
+// It returns the owner of the api key, if the api key was accepted.
+func (d Doctor) api() (*report.ClockifyUser, []Check) {
+	if d.ApiKey == "" {
+		return nil, []Check{{Name: "Clockify API", Status: Skipped, Message: "no api-key"}}
+	}
+
+	user, err := d.Clockify.FetchClockifyUser()
+	var statusError report.StatusError
+	if errors.As(err, &statusError) && (statusError.StatusCode == http.StatusUnauthorized || statusError.StatusCode == http.StatusForbidden) {
+		return nil, []Check{{
+			Name:    "Clockify API",
+			Status:  Failed,
+			Message: fmt.Sprintf("the api key was rejected (%s)", statusError.Status),
+			Fix:     "check api-key in the config file or generate a new api key in the Clockify profile settings",
+		}}
+	}
+	if errors.As(err, &statusError) {
+		return nil, []Check{{Name: "Clockify API", Status: Failed, Message: err.Error(), Fix: "try again later, Clockify may be unavailable"}}
+	}
+	if err != nil {
+		return nil, []Check{{
+			Name:    "Clockify API",
+			Status:  Failed,
+			Message: fmt.Sprintf("not reachable: %s", err),
+			Fix:     "check the network connection and the proxy settings (HTTPS_PROXY)",
+		}}
+	}
+
+	checks := []Check{{Name: "Clockify API", Status: OK, Message: fmt.Sprintf("authenticated as %s <%s>", user.Name, user.Email)}}
+	if d.WorkspaceID == "" || d.UserID == "" {
+		return &user, append(checks, Check{Name: "Workspace", Status: Skipped, Message: fmt.Sprintf("no workspace-id or user-id, the ID of the api key owner is %s", user.ID)})
+	}
+
+	workspaces, err := d.Clockify.FetchClockifyWorkspaces()
+	if err != nil {
+		return &user, append(checks, Check{Name: "Workspace", Status: Failed, Message: fmt.Sprintf("could not fetch the workspaces: %s", err), Fix: "try again later"})
+	}
+	workspace, ok := report.FindWorkspace(workspaces, d.WorkspaceID)
+	if !ok {
+		return &user, append(checks, Check{
+			Name:    "Workspace",
+			Status:  Failed,
+			Message: fmt.Sprintf("%s is not a workspace of %s", d.WorkspaceID, user.Email),
+			Fix:     fmt.Sprintf("set workspace-id to one of %s", formatWorkspaces(workspaces)),
+		})
+	}
+	checks = append(checks, Check{Name: "Workspace", Status: OK, Message: fmt.Sprintf("%s (%s)", workspace.Name, workspace.ID)})
+
+	if d.UserID != user.ID {
+		checks = append(checks, Check{
+			Name:    "User",
+			Status:  Warning,
+			Message: fmt.Sprintf("user-id %s is not the owner of the api key (%s), reading the entries of other users requires admin permissions", d.UserID, user.ID),
+			Fix:     fmt.Sprintf("set user-id to %s unless you report for another user", user.ID),
+		})
+	}
+
+	// any week will do, the request fails if the user is not part of the workspace
+	year, month, day := d.Now.UTC().Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if _, err := d.Clockify.FetchClockifyData(start.Format("2006-01-02T15:04:05Z")); err != nil {
+		return &user, append(checks, Check{
+			Name:    "Time entries",
+			Status:  Failed,
+			Message: fmt.Sprintf("the time entries of user %s can not be read: %s", d.UserID, err),
+			Fix:     fmt.Sprintf("check that user-id belongs to the workspace, the ID of the api key owner is %s", user.ID),
+		})
+	}
+
+	return &user, append(checks, Check{Name: "Time entries", Status: OK, Message: fmt.Sprintf("user %s can be read", d.UserID)})
+}
+
+// clipboard checks the tools used by --copy, only Linux and the BSDs need an external tool.
+func (d Doctor) clipboard() Check {
+	if d.GOOS == "darwin" || d.GOOS == "windows" {
+		return Check{Name: "Clipboard", Status: OK, Message: "built-in"}
+	}
+
+	if d.Getenv("WAYLAND_DISPLAY") != "" && d.installed("wl-copy") && d.installed("wl-paste") {
+		return Check{Name: "Clipboard", Status: OK, Message: "wl-clipboard"}
+	}
+	for _, tool := range []string{"xclip", "xsel", "termux-clipboard-set"} {
+		if d.installed(tool) {
+			return Check{Name: "Clipboard", Status: OK, Message: tool}
+		}
+	}
+
+	fix := "install xclip or xsel, e.g. sudo apt install xclip"
+	if d.Getenv("WAYLAND_DISPLAY") != "" {
+		fix = "install wl-clipboard, e.g. sudo apt install wl-clipboard"
+	}
+
+	return Check{Name: "Clipboard", Status: Warning, Message: "no clipboard tool found, --copy will fail", Fix: fix}
+}
+
+// timezone compares the local time zone with the time zone of the Clockify profile.
+func (d Doctor) timezone(user *report.ClockifyUser) Check {
+	if tz := d.Getenv("TZ"); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return Check{Name: "Time zone", Status: Warning, Message: fmt.Sprintf("TZ=%q is not a valid time zone, UTC is used", tz), Fix: "set TZ to a name like Europe/Berlin"}
+		}
+	}
+
+	name, offset := d.Now.Zone()
+	local := fmt.Sprintf("%s (%s)", name, formatOffset(offset))
+	if user == nil || user.Settings.TimeZone == "" {
+		return Check{Name: "Time zone", Status: OK, Message: local + ", not compared with Clockify"}
+	}
+
+	location, err := time.LoadLocation(user.Settings.TimeZone)
+	if err != nil {
+		return Check{Name: "Time zone", Status: OK, Message: fmt.Sprintf("%s, Clockify uses the unknown time zone %s", local, user.Settings.TimeZone)}
+	}
+
+	_, clockifyOffset := d.Now.In(location).Zone()
+	if offset != clockifyOffset {
+		return Check{
+			Name:    "Time zone",
+			Status:  Warning,
+			Message: fmt.Sprintf("%s differs from %s (%s) in Clockify, the current week may start on another day", local, user.Settings.TimeZone, formatOffset(clockifyOffset)),
+			Fix:     fmt.Sprintf("set TZ=%s or change the time zone in the Clockify profile settings", user.Settings.TimeZone),
+		}
+	}
+
+	return Check{Name: "Time zone", Status: OK, Message: fmt.Sprintf("%s, same as Clockify (%s)", local, user.Settings.TimeZone)}
+}
+
+func (d Doctor) installed(tool string) bool {
+	_, err := d.LookPath(tool)
+
+	return err == nil
+}
+
+func formatWorkspaces(workspaces []report.ClockifyWorkspace) string {
+	parts := make([]string, len(workspaces))
+	for i, workspace := range workspaces {
+		parts[i] = fmt.Sprintf("%s (%s)", workspace.ID, workspace.Name)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
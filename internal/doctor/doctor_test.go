@@ -0,0 +1,217 @@
+package doctor
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type clockifyMock struct {
+	user        report.ClockifyUser
+	userErr     error
+	workspaces  []report.ClockifyWorkspace
+	timeEntries error
+}
+
+func (m clockifyMock) FetchClockifyUser() (report.ClockifyUser, error) {
+	return m.user, m.userErr
+}
+
+func (m clockifyMock) FetchClockifyWorkspaces() ([]report.ClockifyWorkspace, error) {
+	return m.workspaces, nil
+}
+
+func (m clockifyMock) FetchClockifyData(start string) ([]report.ClockifyTimeEntry, error) {
+	return nil, m.timeEntries
+}
+
+func makeTestDoctor(t *testing.T, clockify clockifyMock) Doctor {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("api-key: secret-key-1234\n"), 0o600))
+
+	clockify.user.ID = "user1"
+	clockify.user.Name = "Jane"
+	clockify.user.Email = "jane@example.com"
+	clockify.user.Settings.TimeZone = "Europe/Berlin"
+	if clockify.workspaces == nil {
+		clockify.workspaces = []report.ClockifyWorkspace{{ID: "ws1", Name: "ACME"}}
+	}
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	return Doctor{
+		ConfigFile:  configFile,
+		WorkspaceID: "ws1",
+		UserID:      "user1",
+		ApiKey:      "secret-key-1234",
+		Clockify:    clockify,
+		GOOS:        "linux",
+		Getenv:      func(key string) string { return "" },
+		LookPath: func(file string) (string, error) {
+			if file == "xclip" {
+				return "/usr/bin/xclip", nil
+			}
+			return "", errors.New("not found")
+		},
+		Now: time.Date(2026, time.October, 19, 10, 0, 0, 0, berlin),
+	}
+}
+
+func findCheck(checks []Check, name string) Check {
+	for _, check := range checks {
+		if check.Name == name {
+			return check
+		}
+	}
+
+	return Check{}
+}
+
+func TestDoctor_Run_allChecksPass(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+
+	checks := doctor.Run()
+
+	assert.Equal(t, 0, Failures(checks))
+	assert.Equal(t, []Check{
+		{Name: "Config file", Status: OK, Message: doctor.ConfigFile},
+		{Name: "Config permissions", Status: OK, Message: "0600"},
+		{Name: "Setting workspace-id", Status: OK, Message: "ws1"},
+		{Name: "Setting user-id", Status: OK, Message: "user1"},
+		{Name: "Setting api-key", Status: OK, Message: "********1234"},
+		{Name: "Clockify API", Status: OK, Message: "authenticated as Jane <jane@example.com>"},
+		{Name: "Workspace", Status: OK, Message: "ACME (ws1)"},
+		{Name: "Time entries", Status: OK, Message: "user user1 can be read"},
+		{Name: "Clipboard", Status: OK, Message: "xclip"},
+		{Name: "Time zone", Status: OK, Message: "CEST (UTC+02:00), same as Clockify (Europe/Berlin)"},
+	}, checks)
+}
+
+func TestDoctor_Run_missingConfig(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	doctor.ConfigFile = filepath.Join(t.TempDir(), "missing.yaml")
+	doctor.WorkspaceID, doctor.UserID, doctor.ApiKey = "", "", ""
+
+	checks := doctor.Run()
+
+	assert.Equal(t, 4, Failures(checks))
+	assert.Equal(t, Failed, findCheck(checks, "Config file").Status)
+	assert.Equal(t, "run clockify2cats init --workspace <workspace-id> --user <user-id> --api-key <api-key>", findCheck(checks, "Config file").Fix)
+	assert.Equal(t, Check{Name: "Clockify API", Status: Skipped, Message: "no api-key"}, findCheck(checks, "Clockify API"))
+	assert.Equal(t, "CEST (UTC+02:00), not compared with Clockify", findCheck(checks, "Time zone").Message)
+}
+
+func TestDoctor_Run_profile(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	doctor.Profile = "client-b"
+
+	check := findCheck(doctor.Run(), "Config file")
+
+	assert.Equal(t, OK, check.Status)
+	assert.Equal(t, doctor.ConfigFile+", profile client-b", check.Message)
+}
+
+func TestDoctor_Run_readableConfig(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	assert.NoError(t, os.Chmod(doctor.ConfigFile, 0o644))
+
+	check := findCheck(doctor.Run(), "Config permissions")
+
+	assert.Equal(t, Warning, check.Status)
+	assert.Equal(t, "chmod 600 "+doctor.ConfigFile, check.Fix)
+}
+
+func TestDoctor_Run_rejectedApiKey(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{userErr: report.StatusError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}})
+
+	checks := doctor.Run()
+
+	assert.Equal(t, 1, Failures(checks))
+	assert.Equal(t, "the api key was rejected (401 Unauthorized)", findCheck(checks, "Clockify API").Message)
+	assert.Equal(t, Check{}, findCheck(checks, "Workspace"))
+}
+
+func TestDoctor_Run_unreachableApi(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{userErr: errors.New("dial tcp: lookup api.clockify.me: no such host")})
+
+	check := findCheck(doctor.Run(), "Clockify API")
+
+	assert.Equal(t, Failed, check.Status)
+	assert.Equal(t, "not reachable: dial tcp: lookup api.clockify.me: no such host", check.Message)
+	assert.Equal(t, "check the network connection and the proxy settings (HTTPS_PROXY)", check.Fix)
+}
+
+func TestDoctor_Run_unknownWorkspace(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{workspaces: []report.ClockifyWorkspace{{ID: "ws2", Name: "Private"}}})
+
+	check := findCheck(doctor.Run(), "Workspace")
+
+	assert.Equal(t, Failed, check.Status)
+	assert.Equal(t, "set workspace-id to one of ws2 (Private)", check.Fix)
+}
+
+func TestDoctor_Run_otherUser(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{timeEntries: report.StatusError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}})
+	doctor.UserID = "user2"
+
+	checks := doctor.Run()
+
+	assert.Equal(t, Warning, findCheck(checks, "User").Status)
+	assert.Equal(t, "set user-id to user1 unless you report for another user", findCheck(checks, "User").Fix)
+	assert.Equal(t, Failed, findCheck(checks, "Time entries").Status)
+	assert.Equal(t, "the time entries of user user2 can not be read: Clockify API error: 403 Forbidden", findCheck(checks, "Time entries").Message)
+}
+
+func TestDoctor_Run_clipboard(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	doctor.LookPath = func(file string) (string, error) { return "", errors.New("not found") }
+	doctor.Getenv = func(key string) string {
+		if key == "WAYLAND_DISPLAY" {
+			return "wayland-0"
+		}
+		return ""
+	}
+
+	assert.Equal(t, Check{
+		Name:    "Clipboard",
+		Status:  Warning,
+		Message: "no clipboard tool found, --copy will fail",
+		Fix:     "install wl-clipboard, e.g. sudo apt install wl-clipboard",
+	}, findCheck(doctor.Run(), "Clipboard"))
+
+	doctor.GOOS = "darwin"
+	assert.Equal(t, OK, findCheck(doctor.Run(), "Clipboard").Status)
+}
+
+func TestDoctor_Run_timezone(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	doctor.Now = doctor.Now.UTC()
+
+	check := findCheck(doctor.Run(), "Time zone")
+	assert.Equal(t, Warning, check.Status)
+	assert.Equal(t, "UTC (UTC+00:00) differs from Europe/Berlin (UTC+02:00) in Clockify, the current week may start on another day", check.Message)
+	assert.Equal(t, "set TZ=Europe/Berlin or change the time zone in the Clockify profile settings", check.Fix)
+
+	doctor.Getenv = func(key string) string {
+		if key == "TZ" {
+			return "Mars/Olympus"
+		}
+		return ""
+	}
+	assert.Equal(t, `TZ="Mars/Olympus" is not a valid time zone, UTC is used`, findCheck(doctor.Run(), "Time zone").Message)
+}
+
+func TestDoctor_Run_missingUserID(t *testing.T) {
+	doctor := makeTestDoctor(t, clockifyMock{})
+	doctor.UserID = ""
+
+	checks := doctor.Run()
+
+	assert.Equal(t, 1, Failures(checks))
+	assert.Equal(t, Check{Name: "Workspace", Status: Skipped, Message: "no workspace-id or user-id, the ID of the api key owner is user1"}, findCheck(checks, "Workspace"))
+}
@@ -0,0 +1,102 @@
+package flextime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Ledger holds the flex-time delta of every reported week, keyed by ISO week (e.g. "2026-W09").
+type Ledger struct {
+	Weeks map[string]float64 `json:"weeks"`
+}
+
+// Store persists the ledger as JSON file on the local disk.
+type Store struct {
+	Path string
+}
+
+// WeekKey returns the ledger key of a week. A month boundary ("start" or "end") is appended,
+// so both halves of a week spanning two months are recorded separately.
+func WeekKey(year int, week int, monthChange string) string {
+	key := fmt.Sprintf("%04d-W%02d", year, week)
+	if monthChange != "" {
+		key += "/" + monthChange
+	}
+
+	return key
+}
+
+func (s Store) Load() (Ledger, error) {
+	ledger := Ledger{Weeks: map[string]float64{}}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return ledger, err
+	}
+
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return ledger, fmt.Errorf("error parsing flex-time ledger %s: %w", s.Path, err)
+	}
+	if ledger.Weeks == nil {
+		ledger.Weeks = map[string]float64{}
+	}
+
+	return ledger, nil
+}
+
+// Record stores the delta of a week, replacing a previously recorded value for the same week.
+// Recording a whole week replaces its halves and recording a half replaces the whole week,
+// so that every day is counted once.
+func (s Store) Record(key string, delta float64) error {
+	ledger, err := s.Load()
+	if err != nil {
+		return err
+	}
+
+	week, half, _ := strings.Cut(key, "/")
+	if half == "" {
+		delete(ledger.Weeks, week+"/end")
+		delete(ledger.Weeks, week+"/start")
+	} else {
+		delete(ledger.Weeks, week)
+	}
+	ledger.Weeks[key] = delta
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.Path, data, 0o600)
+}
+
+// Balance sums up the opening balance and the deltas of all weeks up to and including the given week.
+// A delta for the given week that is not recorded yet can be passed as pending.
+func (l Ledger) Balance(opening float64, key string, pending float64) float64 {
+	balance := opening
+	week, _, _ := strings.Cut(key, "/")
+
+	// keys sort chronologically, "/end" (first half of a week) sorts before "/start"
+	for k, delta := range l.Weeks {
+		// the whole week overlaps the half that is given
+		if k == week && k != key {
+			continue
+		}
+		if k < key {
+			balance += delta
+		}
+	}
+
+	return balance + pending
+}
@@ -0,0 +1,82 @@
+package flextime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekKey(t *testing.T) {
+	assert.Equal(t, "2026-W09", WeekKey(2026, 9, ""))
+	assert.Equal(t, "2022-W05/end", WeekKey(2022, 5, "end"))
+}
+
+func TestStore_Load_missingFileReturnsEmptyLedger(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "flextime.json")}
+
+	ledger, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, ledger.Weeks)
+}
+
+func TestStore_Record_replacesWeek(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "flextime.json")}
+
+	assert.NoError(t, store.Record("2026-W01", 1.5))
+	assert.NoError(t, store.Record("2026-W02", -0.5))
+	assert.NoError(t, store.Record("2026-W01", 2))
+
+	ledger, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"2026-W01": 2, "2026-W02": -0.5}, ledger.Weeks)
+}
+
+func TestStore_Record_replacesOverlappingHalves(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "flextime.json")}
+
+	assert.NoError(t, store.Record("2022-W05", 3))
+	assert.NoError(t, store.Record("2022-W05/end", 1))
+	assert.NoError(t, store.Record("2022-W05/start", -0.5))
+
+	ledger, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"2022-W05/end": 1, "2022-W05/start": -0.5}, ledger.Weeks)
+	assert.Equal(t, 10.5, ledger.Balance(10, "2022-W06", 0))
+
+	assert.NoError(t, store.Record("2022-W05", 2))
+
+	ledger, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"2022-W05": 2}, ledger.Weeks)
+	assert.Equal(t, 12.0, ledger.Balance(10, "2022-W06", 0))
+}
+
+func TestStore_Load_invalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flextime.json")
+	os.WriteFile(path, []byte("not json"), 0o600)
+
+	_, err := Store{Path: path}.Load()
+	assert.ErrorContains(t, err, "error parsing flex-time ledger")
+}
+
+func TestLedger_Balance(t *testing.T) {
+	ledger := Ledger{Weeks: map[string]float64{
+		"2022-W04":       1,
+		"2022-W05/end":   0.5,
+		"2022-W05/start": -2,
+		"2022-W06":       4,
+	}}
+
+	assert.Equal(t, 10.0, ledger.Balance(10, "2022-W04", 0), "weeks from the ledger before the given week")
+	assert.Equal(t, 11.5, ledger.Balance(10, "2022-W05/start", 0), "first half of a boundary week counts before the second")
+	assert.Equal(t, 12.0, ledger.Balance(10, "2022-W05/end", 1), "pending delta replaces the recorded one")
+	assert.Equal(t, 13.5, ledger.Balance(10, "2022-W07", 0))
+}
+
+func TestLedger_Balance_skipsWholeWeekForHalf(t *testing.T) {
+	ledger := Ledger{Weeks: map[string]float64{"2022-W05": 3}}
+
+	assert.Equal(t, 9.0, ledger.Balance(10, "2022-W05/end", -1), "a half is not added on top of its whole week")
+}
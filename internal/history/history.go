@@ -0,0 +1,311 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/flextime"
+	"github.com/marvincaspar/clockify2cats/internal/output"
+	"github.com/marvincaspar/clockify2cats/internal/report"
+)
+
+const dateFormat = "2006-01-02"
+
+// Row holds the hours of a report row by date ("2006-01-02"), like the rows of the json format.
+type Row struct {
+	CatsID       string             `json:"catsId"`
+	Text         string             `json:"text"`
+	Text2        string             `json:"text2"`
+	TextExternal string             `json:"textExternal"`
+	Absence      bool               `json:"absence,omitempty"`
+	Hours        map[string]float64 `json:"hours"`
+}
+
+// Submission is a report as it was submitted to CATS, with the options to generate it again.
+// Its JSON fields are a superset of the json format.
+type Submission struct {
+	ID            int       `json:"id"`
+	SubmittedAt   time.Time `json:"submittedAt"`
+	Year          int       `json:"year"`
+	Week          int       `json:"week"`
+	MonthBoundary string    `json:"monthBoundary,omitempty"`
+	Category      string    `json:"category"`
+	WithText      bool      `json:"withText,omitempty"`
+	Days          []string  `json:"days"`
+	Rows          []Row     `json:"rows"`
+	Total         float64   `json:"total"`
+}
+
+// History holds all submissions in the order they were recorded.
+type History struct {
+	Submissions []Submission `json:"submissions"`
+}
+
+// Store persists the history as JSON file on the local disk.
+type Store struct {
+	Path string
+}
+
+// Change is a cell whose hours differ between two versions of a report.
+type Change struct {
+	Row  Row
+	Date string
+	Old  float64
+	New  float64
+}
+
+// FromReport takes a snapshot of the rounded hours of a report. The month boundary is "" for a whole week
+// and "start" or "end" for a half of a week spanning a month boundary.
+func FromReport(generated report.Report, monthBoundary string) Submission {
+	submission := Submission{
+		Year:          generated.Year,
+		Week:          generated.Week,
+		MonthBoundary: monthBoundary,
+		Category:      generated.Category,
+		WithText:      generated.WithText,
+		Days:          []string{},
+		Rows:          []Row{},
+		Total:         report.RoundHours(generated.Summary.Total),
+	}
+
+	for _, day := range generated.Days {
+		submission.Days = append(submission.Days, day.Format(dateFormat))
+	}
+
+	for _, catsEntry := range generated.Entries {
+		row := newRow(catsEntry, generated.WithText)
+		for _, day := range submission.Days {
+			row.Hours[day] = report.RoundHours(catsEntry.Durations[day].Hours())
+		}
+		submission.Rows = append(submission.Rows, row)
+	}
+
+	return submission
+}
+
+// Key returns the key of the submitted week, the same as the key of the flex-time ledger.
+func (s Submission) Key() string {
+	return flextime.WeekKey(s.Year, s.Week, s.MonthBoundary)
+}
+
+// Compare returns the cells whose hours differ, in the order of the rows of the old version followed by new rows.
+func Compare(old Submission, new Submission) []Change {
+	rows := []Row{}
+	oldHours := map[string]map[string]float64{}
+	newHours := map[string]map[string]float64{}
+	for _, row := range old.Rows {
+		if _, ok := oldHours[row.key()]; !ok {
+			rows = append(rows, row)
+			oldHours[row.key()] = map[string]float64{}
+		}
+		addHours(oldHours[row.key()], row.Hours)
+	}
+	for _, row := range new.Rows {
+		if _, ok := newHours[row.key()]; !ok {
+			newHours[row.key()] = map[string]float64{}
+			if _, ok := oldHours[row.key()]; !ok {
+				rows = append(rows, row)
+			}
+		}
+		addHours(newHours[row.key()], row.Hours)
+	}
+
+	changes := []Change{}
+	for _, row := range rows {
+		dates := map[string]bool{}
+		for date := range oldHours[row.key()] {
+			dates[date] = true
+		}
+		for date := range newHours[row.key()] {
+			dates[date] = true
+		}
+
+		sorted := make([]string, 0, len(dates))
+		for date := range dates {
+			sorted = append(sorted, date)
+		}
+		sort.Strings(sorted)
+
+		for _, date := range sorted {
+			oldValue, newValue := oldHours[row.key()][date], newHours[row.key()][date]
+			if math.Abs(oldValue-newValue) >= 0.005 {
+				changes = append(changes, Change{Row: Row{CatsID: row.CatsID, Text: row.Text, Text2: row.Text2, TextExternal: row.TextExternal, Absence: row.Absence}, Date: date, Old: oldValue, New: newValue})
+			}
+		}
+	}
+
+	return changes
+}
+
+// ParseSubmission reads a report of the json format or a single submission of the history.
+func ParseSubmission(data []byte) (Submission, error) {
+	var submission Submission
+	if err := json.Unmarshal(data, &submission); err != nil {
+		return submission, fmt.Errorf("not a json report: %w", err)
+	}
+	if submission.Year == 0 || submission.Week == 0 {
+		return submission, fmt.Errorf("not a json report: year and week are missing")
+	}
+
+	var fields struct {
+		WithText *bool `json:"withText"`
+	}
+	json.Unmarshal(data, &fields)
+	if fields.WithText != nil {
+		return submission, nil
+	}
+
+	// older json reports have no withText field, but leave the texts empty without text
+	for _, row := range submission.Rows {
+		if row.Text != "" || row.Text2 != "" || row.TextExternal != "" {
+			submission.WithText = true
+		}
+	}
+
+	return submission, nil
+}
+
+// CorrectionReport reduces the report to the rows with changes, so that it can be pasted over the submitted rows.
+// Rows that no longer exist are added with zero hours.
+func CorrectionReport(generated report.Report, changes []Change) report.Report {
+	changed := map[string]Row{}
+	order := []string{}
+	for _, change := range changes {
+		if _, ok := changed[change.Row.key()]; !ok {
+			changed[change.Row.key()] = change.Row
+			order = append(order, change.Row.key())
+		}
+	}
+
+	correction := generated
+	correction.Entries = []report.CatsEntity{}
+	correction.Summary = report.Summary{}
+	correction.TimeEntries = nil
+	for _, catsEntry := range generated.Entries {
+		key := newRow(catsEntry, generated.WithText).key()
+		if _, ok := changed[key]; !ok {
+			continue
+		}
+		delete(changed, key)
+
+		correction.Entries = append(correction.Entries, catsEntry)
+		if !catsEntry.Absence {
+			for _, day := range generated.Days {
+				correction.Summary.Total += catsEntry.Durations[day.Format(dateFormat)].Hours()
+			}
+		}
+	}
+
+	for _, key := range order {
+		row, ok := changed[key]
+		if !ok {
+			continue
+		}
+		removed := report.CatsEntity{CatsID: row.CatsID, Text: row.Text, Text2: row.Text2, TextExternal: row.TextExternal, Absence: row.Absence, Durations: map[string]time.Duration{}}
+		for _, day := range generated.Days {
+			removed.Durations[day.Format(dateFormat)] = 0
+		}
+		correction.Entries = append(correction.Entries, removed)
+	}
+
+	return correction
+}
+
+// newRow returns a row without hours, the texts are left empty for reports without text.
+func newRow(catsEntry report.CatsEntity, withText bool) Row {
+	row := Row{CatsID: catsEntry.CatsID, Absence: catsEntry.Absence, Hours: map[string]float64{}}
+	if withText {
+		row.Text, row.Text2, row.TextExternal = catsEntry.Text, catsEntry.Text2, catsEntry.TextExternal
+	}
+
+	return row
+}
+
+func (r Row) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%t", r.CatsID, r.Text, r.Text2, r.TextExternal, r.Absence)
+}
+
+func addHours(sum map[string]float64, hours map[string]float64) {
+	for date, value := range hours {
+		sum[date] = report.RoundHours(sum[date] + value)
+	}
+}
+
+func (s Store) Load() (History, error) {
+	history := History{Submissions: []Submission{}}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return history, err
+	}
+
+	if err := json.Unmarshal(data, &history); err != nil {
+		return history, fmt.Errorf("error parsing submission history %s: %w", s.Path, err)
+	}
+	if history.Submissions == nil {
+		history.Submissions = []Submission{}
+	}
+
+	return history, nil
+}
+
+// Record appends a submission with the next free ID and returns it.
+func (s Store) Record(submission Submission) (Submission, error) {
+	history, err := s.Load()
+	if err != nil {
+		return submission, err
+	}
+
+	submission.ID = 1
+	for _, recorded := range history.Submissions {
+		if recorded.ID >= submission.ID {
+			submission.ID = recorded.ID + 1
+		}
+	}
+	history.Submissions = append(history.Submissions, submission)
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return submission, err
+	}
+
+	return submission, output.WriteFile(s.Path, data, 0o644)
+}
+
+// Find returns the submission with the given ID.
+func (h History) Find(id int) (Submission, bool) {
+	for _, submission := range h.Submissions {
+		if submission.ID == id {
+			return submission, true
+		}
+	}
+
+	return Submission{}, false
+}
+
+// Latest returns the last submission of every week, the most recent week first.
+func (h History) Latest() []Submission {
+	latest := map[string]Submission{}
+	for _, submission := range h.Submissions {
+		latest[submission.Key()] = submission
+	}
+
+	submissions := make([]Submission, 0, len(latest))
+	for _, submission := range latest {
+		submissions = append(submissions, submission)
+	}
+	// keys sort chronologically, "/end" (first half of a week) sorts before "/start"
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].Key() > submissions[j].Key()
+	})
+
+	return submissions
+}
@@ -0,0 +1,180 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marvincaspar/clockify2cats/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestReport() report.Report {
+	monday := time.Date(2026, time.August, 31, 0, 0, 0, 0, time.UTC)
+	return report.Report{
+		Year:     2026,
+		Week:     36,
+		Category: "ID",
+		Days:     []time.Time{monday, monday.AddDate(0, 0, 1)},
+		Entries: []report.CatsEntity{
+			{CatsID: "CATSID-1", Text: "Task", Durations: map[string]time.Duration{
+				"2026-08-31": 90 * time.Minute,
+				"2026-09-01": 20 * time.Minute,
+				"2026-09-02": 8 * time.Hour, // outside of the report days
+			}},
+			{CatsID: "0100", Absence: true, Durations: map[string]time.Duration{"2026-09-01": 8 * time.Hour}},
+		},
+		Summary: report.Summary{Total: 1.8333333},
+	}
+}
+
+func TestFromReport(t *testing.T) {
+	submission := FromReport(makeTestReport(), "end")
+
+	assert.Equal(t, Submission{
+		Year:          2026,
+		Week:          36,
+		MonthBoundary: "end",
+		Category:      "ID",
+		Days:          []string{"2026-08-31", "2026-09-01"},
+		Rows: []Row{
+			{CatsID: "CATSID-1", Hours: map[string]float64{"2026-08-31": 1.5, "2026-09-01": 0.33}},
+			{CatsID: "0100", Absence: true, Hours: map[string]float64{"2026-08-31": 0, "2026-09-01": 8}},
+		},
+		Total: 1.83,
+	}, submission)
+	assert.Equal(t, "2026-W36/end", submission.Key())
+}
+
+func TestFromReport_withText(t *testing.T) {
+	generated := makeTestReport()
+	generated.WithText = true
+
+	submission := FromReport(generated, "")
+
+	assert.True(t, submission.WithText)
+	assert.Equal(t, "Task", submission.Rows[0].Text)
+	assert.Equal(t, "2026-W36", submission.Key())
+}
+
+func TestCompare(t *testing.T) {
+	old := Submission{Rows: []Row{
+		{CatsID: "CATSID-1", Hours: map[string]float64{"2026-08-31": 1.5, "2026-09-01": 2}},
+		{CatsID: "CATSID-2", Hours: map[string]float64{"2026-08-31": 3}},
+	}}
+	new := Submission{Rows: []Row{
+		{CatsID: "CATSID-3", Hours: map[string]float64{"2026-09-01": 1}},
+		{CatsID: "CATSID-1", Hours: map[string]float64{"2026-08-31": 1.5, "2026-09-01": 2.5}},
+	}}
+
+	assert.Equal(t, []Change{
+		{Row: Row{CatsID: "CATSID-1"}, Date: "2026-09-01", Old: 2, New: 2.5},
+		{Row: Row{CatsID: "CATSID-2"}, Date: "2026-08-31", Old: 3, New: 0},
+		{Row: Row{CatsID: "CATSID-3"}, Date: "2026-09-01", Old: 0, New: 1},
+	}, Compare(old, new))
+	assert.Empty(t, Compare(old, old))
+}
+
+func TestCompare_ignoresRounding(t *testing.T) {
+	old := Submission{Rows: []Row{{CatsID: "CATSID-1", Hours: map[string]float64{"2026-08-31": 1.33}}}}
+	new := Submission{Rows: []Row{{CatsID: "CATSID-1", Hours: map[string]float64{"2026-08-31": 1.333}}}}
+
+	assert.Empty(t, Compare(old, new))
+}
+
+func TestStore_Load_missingFile(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "history.json")}
+
+	history, err := store.Load()
+
+	assert.NoError(t, err)
+	assert.Empty(t, history.Submissions)
+}
+
+func TestStore_Load_invalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Store{Path: path}.Load()
+
+	assert.ErrorContains(t, err, "error parsing submission history")
+}
+
+func TestStore_Record(t *testing.T) {
+	store := Store{Path: filepath.Join(t.TempDir(), "history.json")}
+
+	first, err := store.Record(Submission{Year: 2026, Week: 35})
+	assert.NoError(t, err)
+	second, err := store.Record(Submission{Year: 2026, Week: 36, MonthBoundary: "end"})
+	assert.NoError(t, err)
+	third, err := store.Record(Submission{Year: 2026, Week: 35, Total: 40})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3}, []int{first.ID, second.ID, third.ID})
+
+	history, err := store.Load()
+	assert.NoError(t, err)
+	assert.Len(t, history.Submissions, 3)
+
+	found, ok := history.Find(2)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-W36/end", found.Key())
+	_, ok = history.Find(4)
+	assert.False(t, ok)
+
+	latest := history.Latest()
+	assert.Equal(t, []int{2, 3}, []int{latest[0].ID, latest[1].ID})
+}
+
+func TestParseSubmission_jsonFormat(t *testing.T) {
+	var output strings.Builder
+	assert.NoError(t, report.JSONFormatter{}.Format(&output, makeTestReport()))
+
+	submission, err := ParseSubmission([]byte(output.String()))
+
+	assert.NoError(t, err)
+	assert.Empty(t, Compare(FromReport(makeTestReport(), ""), submission))
+	assert.Equal(t, "2026-W36", submission.Key())
+	assert.False(t, submission.WithText)
+}
+
+func TestParseSubmission_withText(t *testing.T) {
+	submission, err := ParseSubmission([]byte(`{"year":2026,"week":36,"rows":[{"catsId":"CATSID-1","text":"Task","hours":{"2026-08-31":1.5}}]}`))
+
+	assert.NoError(t, err)
+	assert.True(t, submission.WithText)
+}
+
+func TestParseSubmission_withTextField(t *testing.T) {
+	submission, err := ParseSubmission([]byte(`{"year":2026,"week":36,"withText":true,"rows":[{"catsId":"CATSID-1","text":"","hours":{"2026-08-31":1.5}}]}`))
+
+	assert.NoError(t, err)
+	assert.True(t, submission.WithText, "empty texts of a report with text columns")
+}
+
+func TestParseSubmission_invalid(t *testing.T) {
+	_, err := ParseSubmission([]byte("CATSID-1\t1,50"))
+	assert.ErrorContains(t, err, "not a json report")
+
+	_, err = ParseSubmission([]byte(`{"rows":[]}`))
+	assert.EqualError(t, err, "not a json report: year and week are missing")
+}
+
+func TestCorrectionReport(t *testing.T) {
+	generated := makeTestReport()
+	old := FromReport(generated, "")
+	old.Rows[0].Hours["2026-09-01"] = 1
+	old.Rows = append(old.Rows, Row{CatsID: "CATSID-2", Hours: map[string]float64{"2026-08-31": 2}})
+
+	correction := CorrectionReport(generated, Compare(old, FromReport(generated, "")))
+
+	assert.Len(t, correction.Entries, 2)
+	assert.Equal(t, "CATSID-1", correction.Entries[0].CatsID)
+	assert.Equal(t, 20*time.Minute, correction.Entries[0].Durations["2026-09-01"])
+	assert.Equal(t, "CATSID-2", correction.Entries[1].CatsID)
+	assert.Equal(t, map[string]time.Duration{"2026-08-31": 0, "2026-09-01": 0}, correction.Entries[1].Durations)
+	assert.InDelta(t, 1.83, correction.Summary.Total, 0.01)
+	assert.Equal(t, generated.Days, correction.Days)
+}
@@ -0,0 +1,100 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Calendar combines the built-in holidays of a state with custom holidays.
+type Calendar struct {
+	State  string
+	Custom []Custom
+}
+
+// Custom is a holiday from a custom holiday file. Holidays without a year repeat every year.
+type Custom struct {
+	Year  int
+	Month time.Month
+	Day   int
+	Name  string
+}
+
+// Holiday returns the name of the holiday on the given date, if any.
+func (c Calendar) Holiday(date time.Time) (string, bool) {
+	for _, custom := range c.Custom {
+		if (custom.Year == 0 || custom.Year == date.Year()) && custom.Month == date.Month() && custom.Day == date.Day() {
+			return custom.Name, true
+		}
+	}
+
+	if c.State == "" {
+		return "", false
+	}
+
+	holidays, err := ForState(c.State, date.Year())
+	if err != nil {
+		return "", false
+	}
+	for _, h := range holidays {
+		if h.Date.Month() == date.Month() && h.Date.Day() == date.Day() {
+			return h.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// LoadFile reads custom holidays from a file with one holiday per line, e.g.
+//
+//	2026-12-24 Heiligabend
+//	12-31 Silvester
+//
+// Empty lines and lines starting with "#" are ignored.
+func LoadFile(path string) ([]Custom, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	holidays := []Custom{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		date, name, _ := strings.Cut(line, " ")
+		custom, err := parseCustomDate(date)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNumber, err)
+		}
+
+		custom.Name = strings.TrimSpace(name)
+		if custom.Name == "" {
+			custom.Name = "Holiday"
+		}
+		holidays = append(holidays, custom)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return holidays, nil
+}
+
+func parseCustomDate(value string) (Custom, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return Custom{Year: date.Year(), Month: date.Month(), Day: date.Day()}, nil
+	}
+	if date, err := time.Parse("01-02", value); err == nil {
+		return Custom{Month: date.Month(), Day: date.Day()}, nil
+	}
+
+	return Custom{}, fmt.Errorf("invalid date %q: must be YYYY-MM-DD or MM-DD", value)
+}
@@ -0,0 +1,66 @@
+package holiday
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_Holiday(t *testing.T) {
+	calendar := Calendar{
+		State: "BY",
+		Custom: []Custom{
+			{Month: time.December, Day: 24, Name: "Heiligabend"},
+			{Year: 2026, Month: time.June, Day: 5, Name: "Brückentag"},
+		},
+	}
+
+	tests := []struct {
+		date     time.Time
+		wantName string
+		wantOk   bool
+	}{
+		{date: time.Date(2026, time.January, 6, 0, 0, 0, 0, time.UTC), wantName: "Heilige Drei Könige", wantOk: true},
+		{date: time.Date(2025, time.December, 24, 0, 0, 0, 0, time.UTC), wantName: "Heiligabend", wantOk: true},
+		{date: time.Date(2026, time.June, 5, 0, 0, 0, 0, time.UTC), wantName: "Brückentag", wantOk: true},
+		{date: time.Date(2027, time.June, 5, 0, 0, 0, 0, time.UTC), wantOk: false},
+		{date: time.Date(2026, time.January, 7, 0, 0, 0, 0, time.UTC), wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date.Format("2006-01-02"), func(t *testing.T) {
+			name, ok := calendar.Holiday(tt.date)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestCalendar_Holiday_withoutState(t *testing.T) {
+	_, ok := Calendar{}.Holiday(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	os.WriteFile(path, []byte("# company holidays\n\n2026-12-24 Heiligabend\n12-31 Silvester\n08-15\n"), 0o600)
+
+	holidays, err := LoadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Custom{
+		{Year: 2026, Month: time.December, Day: 24, Name: "Heiligabend"},
+		{Month: time.December, Day: 31, Name: "Silvester"},
+		{Month: time.August, Day: 15, Name: "Holiday"},
+	}, holidays)
+}
+
+func TestLoadFile_invalidDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	os.WriteFile(path, []byte("2026-12-24 Heiligabend\n24.12.2026 Heiligabend\n"), 0o600)
+
+	_, err := LoadFile(path)
+	assert.EqualError(t, err, path+`:2: invalid date "24.12.2026": must be YYYY-MM-DD or MM-DD`)
+}
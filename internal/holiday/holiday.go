@@ -0,0 +1,168 @@
+package holiday
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// rule computes the date of a holiday for a year and restricts it to a set of states.
+// An empty states list means the holiday applies nationwide.
+type rule struct {
+	name   string
+	date   func(year int) time.Time
+	states []string
+	since  int
+}
+
+var stateNames = map[string]string{
+	"BW": "Baden-Württemberg",
+	"BY": "Bayern",
+	"BE": "Berlin",
+	"BB": "Brandenburg",
+	"HB": "Bremen",
+	"HH": "Hamburg",
+	"HE": "Hessen",
+	"MV": "Mecklenburg-Vorpommern",
+	"NI": "Niedersachsen",
+	"NW": "Nordrhein-Westfalen",
+	"RP": "Rheinland-Pfalz",
+	"SL": "Saarland",
+	"SN": "Sachsen",
+	"ST": "Sachsen-Anhalt",
+	"SH": "Schleswig-Holstein",
+	"TH": "Thüringen",
+}
+
+var rules = []rule{
+	{name: "Neujahr", date: fixed(time.January, 1)},
+	{name: "Heilige Drei Könige", date: fixed(time.January, 6), states: []string{"BW", "BY", "ST"}},
+	{name: "Internationaler Frauentag", date: fixed(time.March, 8), states: []string{"BE"}, since: 2019},
+	{name: "Internationaler Frauentag", date: fixed(time.March, 8), states: []string{"MV"}, since: 2023},
+	{name: "Karfreitag", date: easterOffset(-2)},
+	{name: "Ostersonntag", date: easterOffset(0), states: []string{"BB"}},
+	{name: "Ostermontag", date: easterOffset(1)},
+	{name: "Tag der Arbeit", date: fixed(time.May, 1)},
+	{name: "Christi Himmelfahrt", date: easterOffset(39)},
+	{name: "Pfingstsonntag", date: easterOffset(49), states: []string{"BB"}},
+	{name: "Pfingstmontag", date: easterOffset(50)},
+	{name: "Fronleichnam", date: easterOffset(60), states: []string{"BW", "BY", "HE", "NW", "RP", "SL"}},
+	{name: "Mariä Himmelfahrt", date: fixed(time.August, 15), states: []string{"SL"}},
+	{name: "Weltkindertag", date: fixed(time.September, 20), states: []string{"TH"}, since: 2019},
+	{name: "Tag der Deutschen Einheit", date: fixed(time.October, 3)},
+	{name: "Reformationstag", date: fixed(time.October, 31), states: []string{"BB", "MV", "SN", "ST", "TH"}},
+	{name: "Reformationstag", date: fixed(time.October, 31), states: []string{"HB", "HH", "NI", "SH"}, since: 2018},
+	{name: "Allerheiligen", date: fixed(time.November, 1), states: []string{"BW", "BY", "NW", "RP", "SL"}},
+	{name: "Buß- und Bettag", date: repentanceDay, states: []string{"SN"}},
+	{name: "1. Weihnachtstag", date: fixed(time.December, 25)},
+	{name: "2. Weihnachtstag", date: fixed(time.December, 26)},
+}
+
+// States returns the supported state codes, e.g. "BY" for Bayern.
+func States() []string {
+	states := make([]string, 0, len(stateNames))
+	for code := range stateNames {
+		states = append(states, code)
+	}
+	sort.Strings(states)
+
+	return states
+}
+
+// ForState returns the public holidays of a German state for the given year, sorted by date.
+// The state "DE" only returns the nationwide holidays.
+func ForState(state string, year int) ([]Holiday, error) {
+	state = strings.ToUpper(strings.TrimSpace(state))
+	if _, ok := stateNames[state]; !ok && state != "DE" {
+		return nil, fmt.Errorf("unknown state %q: must be one of DE, %s", state, strings.Join(States(), ", "))
+	}
+
+	holidays := []Holiday{}
+	for _, rule := range rules {
+		if year < rule.since || !rule.appliesTo(state) {
+			continue
+		}
+		holidays = append(holidays, Holiday{Date: rule.date(year), Name: rule.name})
+	}
+
+	// 500th anniversary of the Reformation was a nationwide holiday
+	if year == 2017 && !containsName(holidays, "Reformationstag") {
+		holidays = append(holidays, Holiday{Date: fixed(time.October, 31)(year), Name: "Reformationstag"})
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+
+	return holidays, nil
+}
+
+func (r rule) appliesTo(state string) bool {
+	if len(r.states) == 0 {
+		return true
+	}
+
+	for _, s := range r.states {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsName(holidays []Holiday, name string) bool {
+	for _, h := range holidays {
+		if h.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fixed(month time.Month, day int) func(year int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func easterOffset(days int) func(year int) time.Time {
+	return func(year int) time.Time {
+		return easterSunday(year).AddDate(0, 0, days)
+	}
+}
+
+// easterSunday calculates Easter Sunday with the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// repentanceDay returns the Wednesday before November 23rd.
+func repentanceDay(year int) time.Time {
+	date := time.Date(year, time.November, 22, 0, 0, 0, 0, time.UTC)
+	for date.Weekday() != time.Wednesday {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	return date
+}
@@ -0,0 +1,73 @@
+package holiday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasterSunday(t *testing.T) {
+	assert.Equal(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), easterSunday(2024))
+	assert.Equal(t, time.Date(2025, time.April, 20, 0, 0, 0, 0, time.UTC), easterSunday(2025))
+	assert.Equal(t, time.Date(2026, time.April, 5, 0, 0, 0, 0, time.UTC), easterSunday(2026))
+}
+
+func TestRepentanceDay(t *testing.T) {
+	assert.Equal(t, time.Date(2024, time.November, 20, 0, 0, 0, 0, time.UTC), repentanceDay(2024))
+	assert.Equal(t, time.Date(2026, time.November, 18, 0, 0, 0, 0, time.UTC), repentanceDay(2026))
+}
+
+func holidayNames(holidays []Holiday) []string {
+	names := []string{}
+	for _, h := range holidays {
+		names = append(names, h.Date.Format("01-02")+" "+h.Name)
+	}
+	return names
+}
+
+func TestForState(t *testing.T) {
+	tests := []struct {
+		state string
+		year  int
+		count int
+		has   []string
+		hasNo []string
+	}{
+		{state: "DE", year: 2026, count: 9, has: []string{"04-03 Karfreitag", "05-14 Christi Himmelfahrt", "10-03 Tag der Deutschen Einheit"}},
+		{state: "BY", year: 2026, count: 12, has: []string{"01-06 Heilige Drei Könige", "06-04 Fronleichnam", "11-01 Allerheiligen"}, hasNo: []string{"10-31 Reformationstag"}},
+		{state: "SN", year: 2026, count: 11, has: []string{"10-31 Reformationstag", "11-18 Buß- und Bettag"}},
+		{state: "be", year: 2018, count: 9, hasNo: []string{"03-08 Internationaler Frauentag"}},
+		{state: "BE", year: 2019, count: 10, has: []string{"03-08 Internationaler Frauentag"}},
+		{state: "NI", year: 2017, count: 10, has: []string{"10-31 Reformationstag"}},
+		{state: "NI", year: 2016, count: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			holidays, err := ForState(tt.state, tt.year)
+			assert.NoError(t, err)
+
+			names := holidayNames(holidays)
+			assert.Len(t, names, tt.count)
+			for _, name := range tt.has {
+				assert.Contains(t, names, name)
+			}
+			for _, name := range tt.hasNo {
+				assert.NotContains(t, names, name)
+			}
+		})
+	}
+}
+
+func TestForState_sortedByDate(t *testing.T) {
+	holidays, _ := ForState("BB", 2026)
+	for i := 1; i < len(holidays); i++ {
+		assert.False(t, holidays[i].Date.Before(holidays[i-1].Date))
+	}
+}
+
+func TestForState_unknownState(t *testing.T) {
+	_, err := ForState("XX", 2026)
+	assert.ErrorContains(t, err, `unknown state "XX"`)
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is the profile name used for {profile} as long as no profile is selected.
+const DefaultProfile = "default"
+
+var placeholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Placeholders are the values for file name templates like "cats-{year}-W{week}.tsv".
+type Placeholders struct {
+	Year    int
+	Week    int
+	Profile string
+}
+
+func (p Placeholders) values() map[string]string {
+	profile := p.Profile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	return map[string]string{
+		"year":    fmt.Sprintf("%04d", p.Year),
+		"week":    fmt.Sprintf("%02d", p.Week),
+		"profile": profile,
+	}
+}
+
+// Expand replaces all placeholders in the pattern. The week is padded to two digits, so file names sort by week.
+func (p Placeholders) Expand(pattern string) (string, error) {
+	values := p.values()
+
+	var unknown []string
+	expanded := placeholderPattern.ReplaceAllStringFunc(pattern, func(match string) string {
+		name := strings.ToLower(match[1 : len(match)-1])
+		value, ok := values[name]
+		if !ok {
+			unknown = append(unknown, match)
+			return match
+		}
+
+		return value
+	})
+
+	if len(unknown) > 0 {
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, "{"+name+"}")
+		}
+		sort.Strings(names)
+
+		return "", fmt.Errorf("unknown placeholder %s: must be one of %s", strconv.Quote(unknown[0]), strings.Join(names, ", "))
+	}
+
+	return expanded, nil
+}
+
+// WriteFile writes the data to a temporary file next to the target and renames it afterwards,
+// so the target is either replaced completely or left untouched. Missing directories are created.
+// The temporary file gets the permissions before it is renamed, so the target never has other permissions.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholders_Expand(t *testing.T) {
+	placeholders := Placeholders{Year: 2026, Week: 9}
+
+	path, err := placeholders.Expand("reports/{year}/{profile}-W{week}.tsv")
+	assert.NoError(t, err)
+	assert.Equal(t, "reports/2026/default-W09.tsv", path)
+
+	placeholders.Profile = "work"
+	path, err = placeholders.Expand("{PROFILE}.tsv")
+	assert.NoError(t, err)
+	assert.Equal(t, "work.tsv", path)
+}
+
+func TestPlaceholders_Expand_unknownPlaceholder(t *testing.T) {
+	_, err := Placeholders{}.Expand("{year}-{month}.tsv")
+	assert.EqualError(t, err, `unknown placeholder "{month}": must be one of {profile}, {week}, {year}`)
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2026", "report.tsv")
+
+	assert.NoError(t, WriteFile(path, []byte("first"), 0o644))
+	assert.NoError(t, WriteFile(path, []byte("second"), 0o644))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
+
+func TestWriteFile_permissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+
+	assert.NoError(t, WriteFile(path, []byte("api-key: secret"), 0o600))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "the replaced file is not readable by others")
+}
+
+func TestWriteFile_keepsTargetOnError(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.tsv")
+	assert.NoError(t, os.WriteFile(path, []byte("old"), 0o644))
+	assert.NoError(t, os.Chmod(dir, 0o555))
+	defer os.Chmod(dir, 0o755)
+
+	assert.Error(t, WriteFile(path, []byte("new"), 0o644))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+}
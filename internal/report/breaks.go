@@ -0,0 +1,149 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BreakRule requires a minimum break once the worked time of a day exceeds After.
+type BreakRule struct {
+	After   time.Duration `mapstructure:"after"`
+	Minimum time.Duration `mapstructure:"minimum"`
+}
+
+// BreakRules configures the automatic break deduction. Without rules no break is deducted.
+// Missing break time is deducted from the CATS ID, if time was tracked on it that day,
+// otherwise from the largest row of the day.
+type BreakRules struct {
+	Rules  []BreakRule `mapstructure:"rules"`
+	CatsID string      `mapstructure:"cats-id"`
+}
+
+// Deduction is a missing break that was deducted from a row of the report.
+type Deduction struct {
+	Date     time.Time
+	CatsID   string
+	Duration time.Duration
+	Worked   time.Duration
+	Taken    time.Duration
+	Required time.Duration
+}
+
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// required returns the longest minimum break of all rules whose threshold is exceeded.
+func (b BreakRules) required(worked time.Duration) time.Duration {
+	var required time.Duration
+	for _, rule := range b.Rules {
+		if worked > rule.After && rule.Minimum > required {
+			required = rule.Minimum
+		}
+	}
+
+	return required
+}
+
+// applyBreakRules detects days without the required break and deducts the missing break from the report rows.
+// Missing breaks that can't be deducted from a single row are returned as warnings.
+func (r Reporter) applyBreakRules(timeEntries []ClockifyTimeEntry, catsEntries []CatsEntity) ([]Deduction, []string) {
+	if len(r.Breaks.Rules) == 0 {
+		return nil, nil
+	}
+
+	days := map[string][]interval{}
+	for _, timeEntry := range timeEntries {
+		start, err := time.Parse(timeFormat, timeEntry.TimeInterval.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(timeFormat, timeEntry.TimeInterval.End)
+		if err != nil {
+			end = start.Add(parseDuration(timeEntry.TimeInterval.Duration))
+		}
+
+		day := start.Format(dateFormat)
+		days[day] = append(days[day], interval{start: start, end: end})
+	}
+
+	dayKeys := make([]string, 0, len(days))
+	for day := range days {
+		dayKeys = append(dayKeys, day)
+	}
+	sort.Strings(dayKeys)
+
+	deductions := []Deduction{}
+	warnings := []string{}
+	for _, day := range dayKeys {
+		worked, taken := workedAndBreakTime(days[day])
+		required := r.Breaks.required(worked)
+		if taken >= required {
+			continue
+		}
+
+		missing := required - taken
+		date, _ := time.Parse(dateFormat, day)
+		index := r.breakDeductionEntry(catsEntries, day, missing)
+		if index == -1 {
+			warnings = append(warnings, fmt.Sprintf("Missing break of %.2fh on %s could not be deducted from a single row", missing.Hours(), date.Format("Mon 2006-01-02")))
+			continue
+		}
+
+		catsEntries[index].Durations[day] -= missing
+		deductions = append(deductions, Deduction{
+			Date:     date,
+			CatsID:   catsEntries[index].CatsID,
+			Duration: missing,
+			Worked:   worked,
+			Taken:    taken,
+			Required: required,
+		})
+	}
+
+	return deductions, warnings
+}
+
+// workedAndBreakTime sums up the tracked time and the gaps between the entries of a day.
+func workedAndBreakTime(intervals []interval) (time.Duration, time.Duration) {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	var worked, taken time.Duration
+	var end time.Time
+	for i, iv := range intervals {
+		worked += iv.end.Sub(iv.start)
+		if i > 0 && iv.start.After(end) {
+			taken += iv.start.Sub(end)
+		}
+		if iv.end.After(end) {
+			end = iv.end
+		}
+	}
+
+	return worked, taken
+}
+
+// breakDeductionEntry returns the index of the row the missing break is deducted from.
+func (r Reporter) breakDeductionEntry(catsEntries []CatsEntity, day string, missing time.Duration) int {
+	largest := -1
+	configured := -1
+	for i, catsEntry := range catsEntries {
+		if catsEntry.Absence || catsEntry.Durations[day] < missing {
+			continue
+		}
+		if largest == -1 || catsEntry.Durations[day] > catsEntries[largest].Durations[day] {
+			largest = i
+		}
+		if catsEntry.CatsID == r.Breaks.CatsID && (configured == -1 || catsEntry.Durations[day] > catsEntries[configured].Durations[day]) {
+			configured = i
+		}
+	}
+
+	if configured != -1 {
+		return configured
+	}
+
+	return largest
+}
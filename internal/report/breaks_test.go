@@ -0,0 +1,120 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeBreakEntry(start, end, duration, project string) ClockifyTimeEntry {
+	entry := ClockifyTimeEntry{Description: "Task"}
+	entry.TimeInterval.Start = start
+	entry.TimeInterval.End = end
+	entry.TimeInterval.Duration = duration
+	entry.Project.Name = project
+	return entry
+}
+
+var germanBreakRules = []BreakRule{
+	{After: 6 * time.Hour, Minimum: 30 * time.Minute},
+	{After: 9 * time.Hour, Minimum: 45 * time.Minute},
+}
+
+func TestWorkedAndBreakTime(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2022, 1, 3, hour, minute, 0, 0, time.UTC) }
+
+	worked, taken := workedAndBreakTime([]interval{
+		{start: at(13, 0), end: at(17, 0)},
+		{start: at(9, 0), end: at(12, 0)},
+		{start: at(11, 0), end: at(12, 30)}, // overlapping entry
+	})
+
+	assert.Equal(t, 8*time.Hour+30*time.Minute, worked)
+	assert.Equal(t, 30*time.Minute, taken)
+}
+
+func TestReporter_Generate_deductsMissingBreakFromLargestEntry(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			makeBreakEntry("2022-01-03T08:00:00Z", "2022-01-03T16:00:00Z", "PT8H", "Project (123)"),
+			makeBreakEntry("2022-01-03T16:00:00Z", "2022-01-03T18:00:00Z", "PT2H", "Project (456)"),
+			makeBreakEntry("2022-01-04T08:00:00Z", "2022-01-04T12:00:00Z", "PT4H", "Project (123)"),
+			makeBreakEntry("2022-01-04T12:30:00Z", "2022-01-04T16:30:00Z", "PT4H", "Project (456)"),
+		}},
+		Breaks: BreakRules{Rules: germanBreakRules},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+
+	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
+	parts := strings.Split(entities[0], "\t")
+	assert.Equal(t, "123", parts[0])
+	assert.Equal(t, "7,25", parts[6], "45 minutes deducted for 10h without break")
+	assert.Equal(t, "4,00", parts[8], "30 minutes break taken on Tuesday")
+
+	assert.Equal(t, 17.25, summary.Total)
+	assert.Equal(t, []Deduction{{
+		Date:     time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC),
+		CatsID:   "123",
+		Duration: 45 * time.Minute,
+		Worked:   10 * time.Hour,
+		Taken:    0,
+		Required: 45 * time.Minute,
+	}}, summary.Deductions)
+}
+
+func TestReporter_Generate_deductsMissingBreakFromConfiguredCatsID(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			makeBreakEntry("2022-01-03T08:00:00Z", "2022-01-03T14:00:00Z", "PT6H", "Project (123)"),
+			makeBreakEntry("2022-01-03T14:10:00Z", "2022-01-03T15:10:00Z", "PT1H", "Internal (456)"),
+		}},
+		Breaks: BreakRules{Rules: germanBreakRules, CatsID: "456"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+
+	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
+	assert.Equal(t, "6,00", strings.Split(entities[0], "\t")[6])
+	assert.Equal(t, "0,67", strings.Split(entities[1], "\t")[6], "20 missing minutes deducted")
+
+	assert.Len(t, summary.Deductions, 1)
+	assert.Equal(t, "456", summary.Deductions[0].CatsID)
+	assert.Equal(t, 10*time.Minute, summary.Deductions[0].Taken)
+}
+
+func TestReporter_Generate_withoutBreakRulesNothingIsDeducted(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			makeBreakEntry("2022-01-03T08:00:00Z", "2022-01-03T18:00:00Z", "PT10H", "Project (123)"),
+		}},
+	}
+
+	_, summary, err := generateTSV(reporter, 2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, summary.Total)
+	assert.Empty(t, summary.Deductions)
+}
+
+func TestReporter_Generate_warnsIfBreakCanNotBeDeducted(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			makeBreakEntry("2022-01-03T08:00:00Z", "2022-01-03T08:20:00Z", "PT20M", "Project (123)"),
+			makeBreakEntry("2022-01-03T08:20:00Z", "2022-01-03T08:40:00Z", "PT20M", "Project (456)"),
+		}},
+		Breaks: BreakRules{Rules: []BreakRule{{After: 30 * time.Minute, Minimum: 30 * time.Minute}}},
+	}
+
+	_, summary, err := generateTSV(reporter, 2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+	assert.Empty(t, summary.Deductions)
+	assert.Equal(t, []string{"Missing break of 0.50h on Mon 2022-01-03 could not be deducted from a single row"}, summary.Warnings)
+}
@@ -0,0 +1,104 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Label names the weeks of the report, e.g. "2026-W36" or "2026-W36–2026-W40" for combined reports.
+func (r Report) Label() string {
+	label := fmt.Sprintf("%04d-W%02d", r.Year, r.Week)
+	if len(r.Days) == 0 {
+		return label
+	}
+
+	year, week := r.Days[len(r.Days)-1].ISOWeek()
+	if year > r.Year || (year == r.Year && week > r.Week) {
+		label += fmt.Sprintf("–%04d-W%02d", year, week)
+	}
+
+	return label
+}
+
+// Combine merges the reports of several weeks into one report with a column for every day.
+// Rows with the same CATS ID and texts are merged, the summaries are added up.
+func Combine(reports []Report) Report {
+	if len(reports) == 0 {
+		return Report{}
+	}
+
+	first, last := reports[0], reports[len(reports)-1]
+	combined := Report{
+		Year:     first.Year,
+		Week:     first.Week,
+		Category: first.Category,
+		WithText: first.WithText,
+		Period:   Period{From: first.Period.From, To: last.Period.To},
+		Summary:  Summary{Excluded: map[string]float64{}},
+	}
+
+	days := map[string]time.Time{}
+	for _, report := range reports {
+		for _, day := range report.Days {
+			days[day.Format(dateFormat)] = day
+		}
+
+		for _, catsEntry := range report.Entries {
+			index := combinedEntryIndex(combined.Entries, catsEntry)
+			if index == -1 {
+				combined.Entries = append(combined.Entries, CatsEntity{
+					CatsID:       catsEntry.CatsID,
+					Text:         catsEntry.Text,
+					Text2:        catsEntry.Text2,
+					TextExternal: catsEntry.TextExternal,
+					Durations:    map[string]time.Duration{},
+					Absence:      catsEntry.Absence,
+				})
+				index = len(combined.Entries) - 1
+			}
+			for day, duration := range catsEntry.Durations {
+				combined.Entries[index].Durations[day] += duration
+			}
+		}
+
+		combined.TimeEntries = append(combined.TimeEntries, report.TimeEntries...)
+
+		summary := report.Summary
+		combined.Summary.Days = append(combined.Summary.Days, summary.Days...)
+		combined.Summary.Total += summary.Total
+		combined.Summary.Absence += summary.Absence
+		combined.Summary.Target += summary.Target
+		combined.Summary.HasTarget = combined.Summary.HasTarget || summary.HasTarget
+		combined.Summary.Deductions = append(combined.Summary.Deductions, summary.Deductions...)
+		combined.Summary.Warnings = append(combined.Summary.Warnings, summary.Warnings...)
+		for reason, hours := range summary.Excluded {
+			combined.Summary.Excluded[reason] += hours
+		}
+	}
+
+	for _, day := range days {
+		combined.Days = append(combined.Days, day)
+	}
+	sort.Slice(combined.Days, func(i, j int) bool { return combined.Days[i].Before(combined.Days[j]) })
+
+	// every row gets a duration for every day, like the rows of a weekly report
+	for _, catsEntry := range combined.Entries {
+		for day := range days {
+			catsEntry.Durations[day] += 0
+		}
+	}
+
+	return combined
+}
+
+func combinedEntryIndex(catsEntries []CatsEntity, catsEntry CatsEntity) int {
+	for i, entry := range catsEntries {
+		if entry.CatsID == catsEntry.CatsID && entry.Text == catsEntry.Text && entry.Text2 == catsEntry.Text2 &&
+			entry.TextExternal == catsEntry.TextExternal && entry.Absence == catsEntry.Absence {
+			return i
+		}
+	}
+
+	return -1
+}
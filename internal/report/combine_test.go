@@ -0,0 +1,81 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeRangeEntries() []ClockifyTimeEntry {
+	makeEntry := func(start, duration, project string) ClockifyTimeEntry {
+		var timeEntry ClockifyTimeEntry
+		timeEntry.Description = "Task"
+		timeEntry.TimeInterval.Start = start
+		timeEntry.TimeInterval.Duration = duration
+		timeEntry.Project.Name = project
+		timeEntry.Billable = true
+		return timeEntry
+	}
+
+	return []ClockifyTimeEntry{
+		makeEntry("2026-08-31T08:00:00.000Z", "PT8H", "Project (CATS1)"), // before the range
+		makeEntry("2026-09-01T08:00:00.000Z", "PT1H", "Project (CATS1)"),
+		makeEntry("2026-09-30T08:00:00.000Z", "PT2H", "Project (CATS2)"),
+		makeEntry("2026-10-01T08:00:00.000Z", "PT8H", "Project (CATS2)"), // after the range
+	}
+}
+
+func TestReporter_GenerateRange_reportPerWeekFilteredAtTheEdges(t *testing.T) {
+	reporter := Reporter{DescriptionDelimiter: "#", Repository: repositoryMock{data: makeRangeEntries()}}
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)
+
+	reports, err := reporter.GenerateRange(from, to, "ID", false)
+	assert.Nil(t, err)
+
+	labels := []string{}
+	total := 0.0
+	for _, report := range reports {
+		labels = append(labels, report.Label())
+		total += report.Summary.Total
+	}
+	assert.Equal(t, []string{"2026-W36", "2026-W37", "2026-W38", "2026-W39", "2026-W40"}, labels)
+	assert.Equal(t, 3.0, total, "entries outside of the range and of the week are not reported")
+
+	assert.Equal(t, Period{From: from, To: time.Date(2026, 9, 6, 0, 0, 0, 0, time.UTC)}, reports[0].Period)
+	assert.Equal(t, 1.0, reports[0].Summary.Total)
+	assert.Len(t, reports[0].Summary.Days, 6, "Monday, the 31st of August, is not part of the summary")
+	assert.Equal(t, Period{From: time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), To: to}, reports[4].Period)
+	assert.Equal(t, 2.0, reports[4].Summary.Total)
+}
+
+func TestReporter_GenerateRange_invalidRange(t *testing.T) {
+	reporter := Reporter{Repository: repositoryMock{}}
+
+	_, err := reporter.GenerateRange(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), "ID", false)
+	assert.EqualError(t, err, "invalid range: 2026-09-01 is before 2026-09-30")
+}
+
+func TestCombine(t *testing.T) {
+	reporter := Reporter{DescriptionDelimiter: "#", Repository: repositoryMock{data: makeRangeEntries()}}
+	reports, err := reporter.GenerateRange(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC), "ID", false)
+	assert.Nil(t, err)
+
+	combined := Combine(reports)
+
+	assert.Equal(t, "2026-W36–2026-W40", combined.Label())
+	assert.Len(t, combined.Days, 35)
+	assert.Len(t, combined.Entries, 2)
+	assert.Equal(t, "CATS1", combined.Entries[0].CatsID)
+	assert.Equal(t, []float64{0, 1}, combined.hours(combined.Entries[0])[:2])
+	assert.Equal(t, 2.0, combined.hours(combined.Entries[1])[30])
+	assert.Equal(t, 3.0, combined.Summary.Total)
+	assert.Len(t, combined.Summary.Days, 30)
+	assert.Len(t, combined.TimeEntries, 2)
+}
+
+func TestReport_Label(t *testing.T) {
+	assert.Equal(t, "2022-W01", makeFormatterReport().Label())
+	assert.Equal(t, "2022-W01", Report{Year: 2022, Week: 1}.Label())
+}
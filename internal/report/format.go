@@ -0,0 +1,149 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formatter renders a generated report, e.g. as tab-separated values to paste into CATS.
+type Formatter interface {
+	Format(w io.Writer, report Report) error
+}
+
+// FormatOptions configures the formatters, e.g. from the config file.
+type FormatOptions struct {
+	Locale   Locale
+	XLSX     XLSXOptions
+	Records  RecordsOptions
+	Template TemplateOptions
+	// Now is the generation time, e.g. for the DTSTAMP of the iCalendar events.
+	Now time.Time
+}
+
+var formatters = map[string]func(options FormatOptions) Formatter{
+	"tsv":      func(options FormatOptions) Formatter { return TSVFormatter{Locale: options.Locale} },
+	"csv":      func(options FormatOptions) Formatter { return CSVFormatter{Locale: options.Locale} },
+	"json":     func(options FormatOptions) Formatter { return JSONFormatter{} },
+	"markdown": func(options FormatOptions) Formatter { return MarkdownFormatter{Locale: options.Locale} },
+	"xlsx": func(options FormatOptions) Formatter {
+		return XLSXFormatter{Options: options.XLSX, Locale: options.Locale}
+	},
+	"records": func(options FormatOptions) Formatter {
+		return RecordsFormatter{Options: options.Records, Locale: options.Locale}
+	},
+	"html": func(options FormatOptions) Formatter { return HTMLFormatter{Locale: options.Locale} },
+	"ics":  func(options FormatOptions) Formatter { return ICSFormatter{Now: options.Now} },
+	"template": func(options FormatOptions) Formatter {
+		return TemplateFormatter{Options: options.Template, Locale: options.Locale}
+	},
+}
+
+// binaryFormats can't be printed to the terminal or copied to the clipboard.
+var binaryFormats = map[string]bool{
+	"xlsx": true,
+}
+
+// machineReadableFormats are read by other tools, nothing else may be printed to stdout with them.
+var machineReadableFormats = map[string]bool{
+	"json":    true,
+	"ics":     true,
+	"records": true,
+}
+
+// extensions are the file extensions of the formats, used for file names in --output-dir.
+var extensions = map[string]string{
+	"tsv":      ".tsv",
+	"csv":      ".csv",
+	"json":     ".json",
+	"markdown": ".md",
+	"xlsx":     ".xlsx",
+	"records":  ".txt",
+	"html":     ".html",
+	"ics":      ".ics",
+	"template": ".txt",
+}
+
+// FormatterNames returns the names of all available formatters.
+func FormatterNames() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewFormatter returns the formatter with the given name.
+func NewFormatter(name string, options FormatOptions) (Formatter, error) {
+	newFormatter, ok := formatters[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q: must be one of %s", name, strings.Join(FormatterNames(), ", "))
+	}
+
+	return newFormatter(options), nil
+}
+
+// FileExtension returns the file extension of the format including the dot, e.g. ".md" for markdown.
+func FileExtension(name string) string {
+	extension, ok := extensions[strings.ToLower(name)]
+	if !ok {
+		return ".txt"
+	}
+
+	return extension
+}
+
+// IsBinaryFormat reports whether the format has to be written to a file.
+func IsBinaryFormat(name string) bool {
+	return binaryFormats[strings.ToLower(name)]
+}
+
+// IsMachineReadableFormat reports whether the format is read by other tools, e.g. piped into jq.
+func IsMachineReadableFormat(name string) bool {
+	return machineReadableFormats[strings.ToLower(name)]
+}
+
+// RoundHours rounds hours to two decimals as shown in the report.
+func RoundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// texts returns the text columns of an entry, which are empty for reports without text.
+func (r Report) texts(entry CatsEntity) (string, string, string) {
+	if !r.WithText {
+		return "", "", ""
+	}
+
+	return entry.Text, entry.Text2, entry.TextExternal
+}
+
+// hours returns the hours of an entry for every day of the report.
+func (r Report) hours(entry CatsEntity) []float64 {
+	hours := make([]float64, len(r.Days))
+	for i, day := range r.Days {
+		hours[i] = entry.Durations[day.Format(dateFormat)].Hours()
+	}
+
+	return hours
+}
+
+// DisplayedTotal sums up the hours of the rows without absence with every cell rounded in the display mode,
+// so that the total matches the sum of the displayed cells.
+func (r Report) DisplayedTotal(format HoursFormat) float64 {
+	total := 0.0
+	for _, entry := range r.Entries {
+		if entry.Absence {
+			continue
+		}
+		for _, hours := range r.hours(entry) {
+			total += format.Round(hours)
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVFormatter renders the report as comma-separated values with a header row.
+type CSVFormatter struct {
+	Locale Locale
+}
+
+func (f CSVFormatter) Format(w io.Writer, report Report) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"Rec. order", "Text", "Text 2", "Text External", "Category"}
+	for _, day := range report.Days {
+		header = append(header, f.Locale.FormatDate(day))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, catsEntry := range report.Entries {
+		text, text2, textExternal := report.texts(catsEntry)
+		record := []string{catsEntry.CatsID, text, text2, textExternal, report.Category}
+		for _, hours := range report.hours(catsEntry) {
+			record = append(record, f.Locale.FormatHours(hours))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,289 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	htmlBarWidth    = 360.0
+	htmlBarHeight   = 20.0
+	htmlBarLabel    = 160.0
+	htmlColumnWidth = 56.0
+	htmlChartHeight = 120.0
+)
+
+// HTMLFormatter renders a self-contained HTML page with embedded CSS and inline SVG charts:
+// the CATS grid, a bar per row, the daily totals and the original Clockify entries in a collapsible section.
+type HTMLFormatter struct {
+	Locale Locale
+}
+
+type htmlPage struct {
+	Lang      string
+	Title     string
+	Period    string
+	WithText  bool
+	Days      []htmlDay
+	Rows      []htmlRow
+	Total     string
+	GridTotal string
+	Target    string
+	HasTarget bool
+	Warnings  []string
+	Bars      htmlChart
+	DayBars   htmlChart
+	Entries   []htmlEntry
+}
+
+type htmlDay struct {
+	Header  string
+	Total   string
+	Holiday string
+}
+
+type htmlRow struct {
+	CatsID       string
+	Text         string
+	Text2        string
+	TextExternal string
+	Absence      bool
+	Hours        []string
+	Total        string
+}
+
+type htmlChart struct {
+	Width  float64
+	Height float64
+	Bars   []htmlBar
+	// HasTarget draws the target hours as line at TargetY.
+	HasTarget bool
+	TargetY   float64
+}
+
+type htmlBar struct {
+	Label  string
+	Value  string
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+type htmlEntry struct {
+	Date        string
+	Time        string
+	Duration    string
+	CatsIDs     string
+	Project     string
+	Description string
+}
+
+var htmlTemplate = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 2rem; }
+h1 { font-size: 1.5rem; margin-bottom: 0; }
+h2 { font-size: 1.1rem; margin-top: 2rem; }
+.period { color: #59636e; margin-top: .25rem; }
+table { border-collapse: collapse; font-size: .9rem; }
+th, td { border-bottom: 1px solid #d1d9e0; padding: .35rem .6rem; text-align: left; }
+th { background: #f6f8fa; }
+td.num, th.num { text-align: right; font-variant-numeric: tabular-nums; }
+tr.total td { font-weight: bold; border-top: 2px solid #1f2328; }
+tr.absence td { color: #59636e; font-style: italic; }
+.warnings { color: #9a6700; }
+svg text { font-size: 12px; fill: #1f2328; }
+svg rect { fill: #0969da; }
+svg line { stroke: #cf222e; stroke-dasharray: 4 2; }
+details summary { cursor: pointer; font-weight: bold; margin-top: 2rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="period">{{.Period}}</p>
+<p>Total: <strong>{{.Total}}</strong>{{if .HasTarget}} · Target: {{.Target}}{{end}}</p>
+{{- if .Warnings}}
+<ul class="warnings">
+{{- range .Warnings}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+<table>
+<thead>
+<tr><th>Rec. order</th>{{if .WithText}}<th>Text</th><th>Text 2</th><th>Text External</th>{{end}}{{range .Days}}<th class="num">{{.Header}}</th>{{end}}<th class="num">Total</th></tr>
+</thead>
+<tbody>
+{{- range .Rows}}
+<tr{{if .Absence}} class="absence"{{end}}><td>{{.CatsID}}</td>{{if $.WithText}}<td>{{.Text}}</td><td>{{.Text2}}</td><td>{{.TextExternal}}</td>{{end}}{{range .Hours}}<td class="num">{{.}}</td>{{end}}<td class="num">{{.Total}}</td></tr>
+{{- end}}
+<tr class="total"><td>Total</td>{{if .WithText}}<td></td><td></td><td></td>{{end}}{{range .Days}}<td class="num" title="{{.Holiday}}">{{.Total}}</td>{{end}}<td class="num">{{.GridTotal}}</td></tr>
+</tbody>
+</table>
+<h2>Projects</h2>
+<svg width="{{.Bars.Width}}" height="{{.Bars.Height}}" viewBox="0 0 {{.Bars.Width}} {{.Bars.Height}}" role="img">
+{{- range .Bars.Bars}}
+<text x="0" y="{{.Y}}" dy="14">{{.Label}}</text>
+<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" rx="2"></rect>
+<text x="{{.X}}" y="{{.Y}}" dx="{{.Width}}" dy="14" transform="translate(6 0)">{{.Value}}</text>
+{{- end}}
+</svg>
+<h2>Daily totals</h2>
+<svg width="{{.DayBars.Width}}" height="{{.DayBars.Height}}" viewBox="0 0 {{.DayBars.Width}} {{.DayBars.Height}}" role="img">
+{{- range .DayBars.Bars}}
+<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" rx="2"></rect>
+<text x="{{.X}}" y="{{$.DayBars.Height}}" dy="-20">{{.Value}}</text>
+<text x="{{.X}}" y="{{$.DayBars.Height}}" dy="-4">{{.Label}}</text>
+{{- end}}
+{{- if .DayBars.HasTarget}}
+<line x1="0" x2="{{.DayBars.Width}}" y1="{{.DayBars.TargetY}}" y2="{{.DayBars.TargetY}}"></line>
+{{- end}}
+</svg>
+<details>
+<summary>Clockify entries ({{len .Entries}})</summary>
+<table>
+<thead>
+<tr><th>Date</th><th>Time</th><th class="num">Duration</th><th>CATS ID</th><th>Project</th><th>Description</th></tr>
+</thead>
+<tbody>
+{{- range .Entries}}
+<tr><td>{{.Date}}</td><td>{{.Time}}</td><td class="num">{{.Duration}}</td><td>{{.CatsIDs}}</td><td>{{.Project}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</details>
+</body>
+</html>
+`))
+
+func (f HTMLFormatter) Format(w io.Writer, report Report) error {
+	total := report.DisplayedTotal(f.Locale.HoursFormat())
+	page := htmlPage{
+		Lang:      f.Locale.String(),
+		Title:     "CATS report " + report.Label(),
+		WithText:  report.WithText,
+		Total:     f.Locale.FormatHoursWithUnit(total),
+		GridTotal: f.Locale.FormatHours(total),
+		Target:    f.Locale.FormatHoursWithUnit(report.Summary.Target),
+		HasTarget: report.Summary.HasTarget,
+		Warnings:  report.Summary.Warnings,
+	}
+	if len(report.Days) > 0 {
+		page.Period = f.Locale.FormatDate(report.Days[0]) + " – " + f.Locale.FormatDate(report.Days[len(report.Days)-1])
+	}
+
+	dayTotals := make([]float64, len(report.Days))
+	rowTotals := make([]float64, len(report.Entries))
+	for i, catsEntry := range report.Entries {
+		text, text2, textExternal := report.texts(catsEntry)
+		row := htmlRow{CatsID: catsEntry.CatsID, Text: text, Text2: text2, TextExternal: textExternal, Absence: catsEntry.Absence}
+		for j, hours := range report.hours(catsEntry) {
+			row.Hours = append(row.Hours, f.Locale.FormatHours(hours))
+			hours = f.Locale.HoursFormat().Round(hours)
+			rowTotals[i] += hours
+			if !catsEntry.Absence {
+				dayTotals[j] += hours
+			}
+		}
+		row.Total = f.Locale.FormatHours(rowTotals[i])
+		page.Rows = append(page.Rows, row)
+	}
+
+	targets := make([]float64, len(report.Days))
+	for i, day := range report.Days {
+		htmlDay := htmlDay{Header: f.Locale.FormatDayHeader(day), Total: f.Locale.FormatHours(dayTotals[i])}
+		if summary, ok := report.Summary.day(day.Format(dateFormat)); ok {
+			htmlDay.Holiday = summary.Holiday
+			targets[i] = summary.Target
+		}
+		page.Days = append(page.Days, htmlDay)
+	}
+
+	page.Bars = f.rowChart(report.Entries, rowTotals)
+	page.DayBars = f.dayChart(page.Days, dayTotals, targets, report.Summary.HasTarget)
+
+	for _, timeEntry := range report.TimeEntries {
+		start, _ := time.Parse(timeFormat, timeEntry.TimeInterval.Start)
+		end, _ := time.Parse(timeFormat, timeEntry.TimeInterval.End)
+		page.Entries = append(page.Entries, htmlEntry{
+			Date:        f.Locale.FormatDate(start),
+			Time:        start.Format("15:04") + "–" + end.Format("15:04"),
+			Duration:    f.Locale.FormatHours(parseDuration(timeEntry.TimeInterval.Duration).Hours()),
+			CatsIDs:     strings.Join(timeEntry.CatsIDs, ", "),
+			Project:     timeEntry.Project.Name,
+			Description: timeEntry.Description,
+		})
+	}
+
+	return htmlTemplate.Execute(w, page)
+}
+
+// rowChart draws a horizontal bar per row, scaled to the largest row.
+func (f HTMLFormatter) rowChart(entries []CatsEntity, totals []float64) htmlChart {
+	chart := htmlChart{Width: htmlBarLabel + htmlBarWidth + 60}
+
+	longest := 0.0
+	for _, total := range totals {
+		if total > longest {
+			longest = total
+		}
+	}
+
+	for i, catsEntry := range entries {
+		bar := htmlBar{
+			Label:  catsEntry.CatsID,
+			Value:  f.Locale.FormatHours(totals[i]),
+			X:      htmlBarLabel,
+			Y:      float64(i) * (htmlBarHeight + 6),
+			Height: htmlBarHeight,
+		}
+		if longest > 0 {
+			bar.Width = RoundHours(totals[i] / longest * htmlBarWidth)
+		}
+		chart.Bars = append(chart.Bars, bar)
+	}
+	chart.Height = float64(len(entries)) * (htmlBarHeight + 6)
+
+	return chart
+}
+
+// dayChart draws a vertical bar per day and the target hours as line, scaled to the longest day or target.
+// The bottom 40 pixels hold the labels.
+func (f HTMLFormatter) dayChart(days []htmlDay, totals []float64, targets []float64, hasTarget bool) htmlChart {
+	chart := htmlChart{Width: float64(len(days)) * htmlColumnWidth, Height: htmlChartHeight + 40}
+
+	longest, target := 0.0, 0.0
+	for i := range totals {
+		if totals[i] > longest {
+			longest = totals[i]
+		}
+		if targets[i] > target {
+			target = targets[i]
+		}
+	}
+	if hasTarget && target > longest {
+		longest = target
+	}
+
+	for i, day := range days {
+		bar := htmlBar{Label: day.Header, Value: day.Total, X: float64(i) * htmlColumnWidth, Y: htmlChartHeight, Width: htmlColumnWidth - 8}
+		if longest > 0 {
+			bar.Height = RoundHours(totals[i] / longest * htmlChartHeight)
+			bar.Y = htmlChartHeight - bar.Height
+		}
+		chart.Bars = append(chart.Bars, bar)
+	}
+	if hasTarget && target > 0 {
+		chart.HasTarget = true
+		chart.TargetY = RoundHours(htmlChartHeight - target/longest*htmlChartHeight)
+	}
+
+	return chart
+}
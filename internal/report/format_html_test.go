@@ -0,0 +1,64 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLFormatter(t *testing.T) {
+	report := makeFormatterReport()
+	var timeEntry ClockifyTimeEntry
+	timeEntry.Description = "Task <b>#1</b>"
+	timeEntry.Project.Name = "Project (CATS-1)"
+	timeEntry.TimeInterval.Start = "2022-01-03T08:00:00Z"
+	timeEntry.TimeInterval.End = "2022-01-03T09:30:00Z"
+	timeEntry.TimeInterval.Duration = "PT1H30M"
+	report.TimeEntries = []ReportedTimeEntry{{ClockifyTimeEntry: timeEntry, CatsIDs: []string{"CATS-1"}}}
+
+	output := formatReport(t, "html", report)
+
+	assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"))
+	assert.Contains(t, output, `<html lang="de-DE">`, "the zero locale formats like de-DE")
+	assert.Contains(t, output, "<title>CATS report 2022-W01</title>")
+	assert.Contains(t, output, "<style>")
+	assert.NotContains(t, output, "<link", "the page is self-contained")
+	assert.NotContains(t, output, "<script")
+	assert.Contains(t, output, `<p class="period">03.01.2022 – 09.01.2022</p>`)
+	assert.Contains(t, output, `<th class="num">Mo 03.01.</th>`)
+	assert.Contains(t, output, `<td>CATS-1</td><td>Task</td><td>Detail | more</td><td></td><td class="num">1,50</td>`)
+	assert.Contains(t, output, `<td class="num">9,50</td></tr>`)
+
+	assert.Contains(t, output, `<rect x="160" y="0" width="360" height="20" rx="2"></rect>`, "the largest row fills the chart")
+	assert.Contains(t, output, `<rect x="112" y="0" width="48" height="120" rx="2"></rect>`, "the longest day fills the chart")
+	assert.Contains(t, output, `<rect x="0" y="97.5" width="48" height="22.5" rx="2"></rect>`)
+
+	assert.Contains(t, output, "<summary>Clockify entries (1)</summary>")
+	assert.Contains(t, output, "<td>03.01.2022</td><td>08:00–09:30</td><td class=\"num\">1,50</td><td>CATS-1</td><td>Project (CATS-1)</td><td>Task &lt;b&gt;#1&lt;/b&gt;</td>")
+}
+
+func TestHTMLFormatter_lang(t *testing.T) {
+	locale, err := NewLocale("en-GB")
+	assert.NoError(t, err)
+
+	output := formatReportWith(t, HTMLFormatter{Locale: locale}, makeFormatterReport())
+
+	assert.Contains(t, output, `<html lang="en-GB">`)
+}
+
+func TestHTMLFormatter_targetLine(t *testing.T) {
+	report := makeFormatterReport()
+	report.Summary.HasTarget = true
+	report.Summary.Target = 16
+	report.Summary.Days = []DaySummary{
+		{Date: report.Days[0], Target: 8, Hours: 1.5},
+		{Date: report.Days[2], Target: 10, Hours: 8},
+	}
+
+	output := formatReport(t, "html", report)
+
+	assert.Contains(t, output, "Target: 16,00h")
+	assert.Contains(t, output, `<line x1="0" x2="392" y1="0" y2="0"></line>`)
+	assert.Contains(t, output, `<rect x="112" y="24" width="48" height="96" rx="2"></rect>`, "days are scaled to the target")
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// ICSFormatter renders the Clockify entries of the report as iCalendar events, so the reported week can be
+// overlaid in a calendar app. The summary of an event starts with the resolved CATS IDs followed by the description.
+type ICSFormatter struct {
+	// Now is the generation time used as DTSTAMP of the events, the current time if zero.
+	Now time.Time
+}
+
+func (f ICSFormatter) Format(w io.Writer, report Report) error {
+	now := f.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//clockify2cats//CATS report//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:CATS " + report.Label(),
+	}
+
+	for _, timeEntry := range report.TimeEntries {
+		start, err := time.Parse(timeFormat, timeEntry.TimeInterval.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(timeFormat, timeEntry.TimeInterval.End)
+		if err != nil {
+			end = start.Add(parseDuration(timeEntry.TimeInterval.Duration))
+		}
+
+		catsIDs := strings.Join(timeEntry.CatsIDs, ", ")
+		summary := catsIDs
+		if timeEntry.Description != "" {
+			summary += " " + timeEntry.Description
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+icsUID(timeEntry),
+			"DTSTAMP:"+now.UTC().Format(icsTimeFormat),
+			"DTSTART:"+start.UTC().Format(icsTimeFormat),
+			"DTEND:"+end.UTC().Format(icsTimeFormat),
+			"SUMMARY:"+icsEscape(summary),
+			"DESCRIPTION:"+icsEscape(fmt.Sprintf("CATS ID: %s\nProject: %s\n%s", catsIDs, timeEntry.Project.Name, timeEntry.Description)),
+			"CATEGORIES:"+icsCategories(timeEntry.CatsIDs),
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var output strings.Builder
+	for _, line := range lines {
+		output.WriteString(icsFold(line))
+		output.WriteString("\r\n")
+	}
+
+	_, err := io.WriteString(w, output.String())
+	return err
+}
+
+// icsUID uses the Clockify ID of the entry, entries without ID get a hash of their start, project and description.
+func icsUID(timeEntry ReportedTimeEntry) string {
+	id := timeEntry.ID
+	if id == "" {
+		hash := sha1.Sum([]byte(timeEntry.TimeInterval.Start + "\n" + timeEntry.Project.Name + "\n" + timeEntry.Description))
+		id = fmt.Sprintf("%x", hash[:8])
+	}
+
+	return id + "@clockify2cats"
+}
+
+// icsEscape escapes text values as defined in RFC 5545, section 3.3.11.
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// icsCategories escapes every CATS ID on its own, the categories are separated by plain commas.
+func icsCategories(catsIDs []string) string {
+	categories := make([]string, len(catsIDs))
+	for i, catsID := range catsIDs {
+		categories[i] = icsEscape(catsID)
+	}
+
+	return strings.Join(categories, ",")
+}
+
+// icsFold splits lines longer than 75 octets, continuation lines start with a space.
+// Lines are only split between UTF-8 characters.
+func icsFold(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if length+size > 75 {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+
+	return folded.String()
+}
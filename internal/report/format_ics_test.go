@@ -0,0 +1,77 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeReportedTimeEntry(id string, start string, end string, project string, description string, catsIDs ...string) ReportedTimeEntry {
+	var timeEntry ClockifyTimeEntry
+	timeEntry.ID = id
+	timeEntry.TimeInterval.Start = start
+	timeEntry.TimeInterval.End = end
+	timeEntry.TimeInterval.Duration = "PT1H30M"
+	timeEntry.Project.Name = project
+	timeEntry.Description = description
+
+	return ReportedTimeEntry{ClockifyTimeEntry: timeEntry, CatsIDs: catsIDs}
+}
+
+func TestICSFormatter(t *testing.T) {
+	report := makeFormatterReport()
+	report.TimeEntries = []ReportedTimeEntry{
+		makeReportedTimeEntry("64a1", "2022-01-03T08:00:00Z", "2022-01-03T09:30:00Z", "Project (CATS-1, CATS-2)", "Review; fixes, tests", "CATS-1", "CATS-2"),
+	}
+
+	formatter, err := NewFormatter("ics", FormatOptions{Now: time.Date(2022, time.January, 10, 12, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	output := formatReportWith(t, formatter, report)
+
+	assert.Equal(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//clockify2cats//CATS report//EN\r\n"+
+		"CALSCALE:GREGORIAN\r\n"+
+		"METHOD:PUBLISH\r\n"+
+		"X-WR-CALNAME:CATS 2022-W01\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:64a1@clockify2cats\r\n"+
+		"DTSTAMP:20220110T120000Z\r\n"+
+		"DTSTART:20220103T080000Z\r\n"+
+		"DTEND:20220103T093000Z\r\n"+
+		"SUMMARY:CATS-1\\, CATS-2 Review\\; fixes\\, tests\r\n"+
+		"DESCRIPTION:CATS ID: CATS-1\\, CATS-2\\nProject: Project (CATS-1\\, CATS-2)\\nR\r\n"+
+		" eview\\; fixes\\, tests\r\n"+
+		"CATEGORIES:CATS-1,CATS-2\r\n"+
+		"TRANSP:TRANSPARENT\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n", output)
+}
+
+func TestICSFormatter_entryWithoutIDAndEnd(t *testing.T) {
+	report := makeFormatterReport()
+	report.TimeEntries = []ReportedTimeEntry{
+		makeReportedTimeEntry("", "2022-01-03T08:00:00Z", "", "Project", "", "-"),
+	}
+
+	output := formatReport(t, "ics", report)
+
+	assert.Regexp(t, `UID:[0-9a-f]{16}@clockify2cats\r\n`, output)
+	assert.Contains(t, output, "DTEND:20220103T093000Z\r\n", "the end is calculated from the duration")
+	assert.Contains(t, output, "SUMMARY:-\r\n")
+}
+
+func TestICSCategories(t *testing.T) {
+	assert.Equal(t, `CATS-1,WBS\,1\;2`, icsCategories([]string{"CATS-1", "WBS,1;2"}))
+}
+
+func TestICSFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("ä", 40)
+
+	folded := strings.Split(icsFold(line), "\r\n")
+	assert.Len(t, folded, 2)
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ä", 33), folded[0], "multi-byte characters are not split")
+	assert.Equal(t, " "+strings.Repeat("ä", 7), folded[1])
+}
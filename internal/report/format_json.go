@@ -0,0 +1,67 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONFormatter renders the report as JSON document with the hours per row and day.
+type JSONFormatter struct{}
+
+type jsonReport struct {
+	Year          int       `json:"year"`
+	Week          int       `json:"week"`
+	MonthBoundary string    `json:"monthBoundary,omitempty"`
+	Category      string    `json:"category"`
+	WithText      bool      `json:"withText"`
+	Days          []string  `json:"days"`
+	Rows          []jsonRow `json:"rows"`
+	Total         float64   `json:"total"`
+}
+
+type jsonRow struct {
+	CatsID       string             `json:"catsId"`
+	Text         string             `json:"text"`
+	Text2        string             `json:"text2"`
+	TextExternal string             `json:"textExternal"`
+	Absence      bool               `json:"absence,omitempty"`
+	Hours        map[string]float64 `json:"hours"`
+}
+
+func (f JSONFormatter) Format(w io.Writer, report Report) error {
+	output := jsonReport{
+		Year:     report.Year,
+		Week:     report.Week,
+		Category: report.Category,
+		WithText: report.WithText,
+		Days:     []string{},
+		Rows:     []jsonRow{},
+		Total:    RoundHours(report.Summary.Total),
+	}
+	output.MonthBoundary, _ = report.MonthBoundary()
+
+	for _, day := range report.Days {
+		output.Days = append(output.Days, day.Format(dateFormat))
+	}
+
+	for _, catsEntry := range report.Entries {
+		text, text2, textExternal := report.texts(catsEntry)
+		row := jsonRow{
+			CatsID:       catsEntry.CatsID,
+			Text:         text,
+			Text2:        text2,
+			TextExternal: textExternal,
+			Absence:      catsEntry.Absence,
+			Hours:        map[string]float64{},
+		}
+		for i, hours := range report.hours(catsEntry) {
+			row.Hours[output.Days[i]] = RoundHours(hours)
+		}
+		output.Rows = append(output.Rows, row)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(output)
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MarkdownFormatter renders the report as Markdown table, the text columns are only included for reports with text.
+type MarkdownFormatter struct {
+	Locale Locale
+}
+
+func (f MarkdownFormatter) Format(w io.Writer, report Report) error {
+	header := []string{"Rec. order"}
+	if report.WithText {
+		header = append(header, "Text", "Text 2", "Text External")
+	}
+	header = append(header, "Category")
+	for _, day := range report.Days {
+		header = append(header, f.Locale.FormatDayHeader(day))
+	}
+
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+		if i >= len(header)-len(report.Days) {
+			separator[i] = "---:"
+		}
+	}
+
+	lines := []string{markdownRow(header), markdownRow(separator)}
+	for _, catsEntry := range report.Entries {
+		row := []string{catsEntry.CatsID}
+		if report.WithText {
+			text, text2, textExternal := report.texts(catsEntry)
+			row = append(row, text, text2, textExternal)
+		}
+		row = append(row, report.Category)
+		for _, hours := range report.hours(catsEntry) {
+			row = append(row, f.Locale.FormatHours(hours))
+		}
+		lines = append(lines, markdownRow(row))
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
@@ -0,0 +1,171 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorBold   = "\033[1m"
+
+	// maxDailyHours is the maximum working time per day allowed by German labor law.
+	maxDailyHours = 10.0
+)
+
+// PreviewFormatter renders a human-readable table with weekday headers, row totals and day totals.
+// Anomalies are highlighted in color, or marked with "!" without color:
+// rows without CATS ID, days with more than 10 hours, time on weekends or holidays
+// and days that don't match the target hours.
+type PreviewFormatter struct {
+	Color  bool
+	Locale Locale
+}
+
+type previewCell struct {
+	text    string
+	color   string
+	numeric bool
+}
+
+func (f PreviewFormatter) Format(w io.Writer, report Report) error {
+	header := []previewCell{{text: "Rec. order"}}
+	if report.WithText {
+		header = append(header, previewCell{text: "Text"}, previewCell{text: "Text 2"}, previewCell{text: "Text External"})
+	}
+	header = append(header, previewCell{text: "Category"})
+	for _, day := range report.Days {
+		header = append(header, previewCell{text: f.Locale.FormatDayHeader(day), numeric: true})
+	}
+	header = append(header, previewCell{text: "Total", numeric: true})
+
+	rows := [][]previewCell{header}
+	dayTotals := make([]float64, len(report.Days))
+	for _, catsEntry := range report.Entries {
+		row := []previewCell{{text: catsEntry.CatsID}}
+		if catsEntry.CatsID == "-" {
+			row[0].color = colorRed
+		}
+		if report.WithText {
+			text, text2, textExternal := report.texts(catsEntry)
+			row = append(row, previewCell{text: text}, previewCell{text: text2}, previewCell{text: textExternal})
+		}
+		row = append(row, previewCell{text: report.Category})
+
+		rowTotal := 0.0
+		for i, hours := range report.hours(catsEntry) {
+			row = append(row, previewCell{text: f.Locale.FormatHours(hours), numeric: true})
+			hours = f.Locale.HoursFormat().Round(hours)
+			rowTotal += hours
+			if !catsEntry.Absence {
+				dayTotals[i] += hours
+			}
+		}
+		row = append(row, previewCell{text: f.Locale.FormatHours(rowTotal), numeric: true})
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, f.totalRow("Total", len(header), report, dayTotals, report.DisplayedTotal(f.Locale.HoursFormat())))
+	if report.Summary.HasTarget {
+		targets := make([]float64, len(report.Days))
+		for i, day := range report.Days {
+			if summary, ok := report.Summary.day(day.Format(dateFormat)); ok {
+				targets[i] = summary.Target
+			}
+		}
+		rows = append(rows, f.plainRow("Target", len(header), report, targets, report.Summary.Target))
+	}
+
+	return f.write(w, rows)
+}
+
+// totalRow sums up the hours per day and highlights anomalies of the day.
+func (f PreviewFormatter) totalRow(label string, columns int, report Report, dayTotals []float64, total float64) []previewCell {
+	row := f.plainRow(label, columns, report, dayTotals, total)
+	offset := columns - len(report.Days) - 1
+
+	for i, day := range report.Days {
+		hours := dayTotals[i]
+		summary, inSummary := report.Summary.day(day.Format(dateFormat))
+
+		switch {
+		case hours > maxDailyHours:
+			row[offset+i].color = colorRed
+		case inSummary && summary.Holiday != "" && hours > 0:
+			row[offset+i].color = colorRed
+		case hours > 0 && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday):
+			row[offset+i].color = colorYellow
+		case inSummary && report.Summary.HasTarget && RoundHours(summary.Delta()) != 0:
+			row[offset+i].color = colorYellow
+		}
+	}
+
+	return row
+}
+
+func (f PreviewFormatter) plainRow(label string, columns int, report Report, hours []float64, total float64) []previewCell {
+	row := make([]previewCell, columns)
+	row[0] = previewCell{text: label, color: colorBold}
+	offset := columns - len(report.Days) - 1
+
+	for i, h := range hours {
+		row[offset+i] = previewCell{text: f.Locale.FormatHours(h), numeric: true}
+	}
+	row[columns-1] = previewCell{text: f.Locale.FormatHours(total), numeric: true, color: colorBold}
+
+	return row
+}
+
+// write aligns all columns. The widths are calculated without color codes, which is why text/tabwriter can't be used.
+func (f PreviewFormatter) write(w io.Writer, rows [][]previewCell) error {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if length := f.width(cell); length > widths[i] {
+				widths[i] = length
+			}
+		}
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			text := cell.text
+			if !f.Color && cell.color != "" && cell.color != colorBold {
+				text += "!"
+			}
+
+			padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(text))
+			if cell.numeric {
+				text = padding + text
+			} else {
+				text = text + padding
+			}
+
+			if f.Color && cell.color != "" {
+				text = cell.color + text + colorReset
+			}
+			cells[i] = text
+		}
+
+		if _, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, "  "), " ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f PreviewFormatter) width(cell previewCell) int {
+	width := utf8.RuneCountInString(cell.text)
+	if !f.Color && cell.color != "" && cell.color != colorBold {
+		width++
+	}
+
+	return width
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makePreviewReport() Report {
+	start := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	first := emptyWeek(start)
+	first["2022-01-03"] = 8 * time.Hour
+	first["2022-01-04"] = 11 * time.Hour
+	missing := emptyWeek(start)
+	missing["2022-01-08"] = time.Hour
+
+	days := []DaySummary{}
+	for _, day := range weekDaysOf(start) {
+		target := 8.0
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			target = 0
+		}
+		days = append(days, DaySummary{Date: day, Target: target})
+	}
+	days[0].Hours = 8
+	days[1].Hours = 11
+	days[5].Hours = 1
+
+	return Report{
+		Year:     2022,
+		Week:     1,
+		Days:     reportColumns(start, nil),
+		Category: "ID",
+		Entries: []CatsEntity{
+			{CatsID: "CATS-1", Durations: first},
+			{CatsID: "-", Durations: missing},
+		},
+		Summary: Summary{Total: 20, Target: 40, HasTarget: true, Days: days},
+	}
+}
+
+func weekDaysOf(start time.Time) []time.Time {
+	days := []time.Time{}
+	for i := 0; i < 7; i++ {
+		days = append(days, start.AddDate(0, 0, i))
+	}
+	return days
+}
+
+func TestPreviewFormatter_withoutColor(t *testing.T) {
+	var output strings.Builder
+	assert.NoError(t, PreviewFormatter{}.Format(&output, makePreviewReport()))
+
+	assert.Equal(t, strings.Join([]string{
+		"Rec. order  Category  Mo 03.01.  Di 04.01.  Mi 05.01.  Do 06.01.  Fr 07.01.  Sa 08.01.  So 09.01.  Total",
+		"CATS-1      ID             8,00      11,00       0,00       0,00       0,00       0,00       0,00  19,00",
+		"-!          ID             0,00       0,00       0,00       0,00       0,00       1,00       0,00   1,00",
+		"Total                      8,00     11,00!      0,00!      0,00!      0,00!      1,00!       0,00  20,00",
+		"Target                     8,00       8,00       8,00       8,00       8,00       0,00       0,00  40,00",
+		"",
+	}, "\n"), output.String())
+}
+
+func TestPreviewFormatter_withColor(t *testing.T) {
+	var output strings.Builder
+	assert.NoError(t, PreviewFormatter{Color: true}.Format(&output, makePreviewReport()))
+
+	lines := strings.Split(output.String(), "\n")
+	assert.True(t, strings.HasPrefix(lines[2], colorRed+"-         "+colorReset), "row without CATS ID is red")
+	assert.Contains(t, lines[3], colorRed+"    11,00"+colorReset, "more than 10 hours are red")
+	assert.Contains(t, lines[3], colorYellow+"     1,00"+colorReset, "weekend hours are yellow")
+	assert.NotContains(t, lines[3], "!")
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RecordsOptions configures the vertical record export for SAP upload programs.
+type RecordsOptions struct {
+	PersonnelNumber string   `mapstructure:"personnel-number"`
+	DateFormat      string   `mapstructure:"date-format"`
+	Fields          []string `mapstructure:"fields"`
+	Separator       string   `mapstructure:"separator"`
+	Header          bool     `mapstructure:"header"`
+}
+
+var recordHeaders = map[string]string{
+	"personnel-number": "PERNR",
+	"date":             "WORKDATE",
+	"cats-id":          "RAUFNR",
+	"category":         "CATEGORY",
+	"hours":            "CATSHOURS",
+	"text":             "SHORTTEXT",
+	"text2":            "LONGTEXT",
+	"text-external":    "EXTTEXT",
+}
+
+var defaultRecordFields = []string{"personnel-number", "date", "cats-id", "category", "hours"}
+
+// Validate checks the configured fields and that the personnel number is set if it is exported.
+func (o RecordsOptions) Validate() error {
+	for _, field := range o.fields() {
+		if _, ok := recordHeaders[field]; !ok {
+			fields := make([]string, 0, len(recordHeaders))
+			for name := range recordHeaders {
+				fields = append(fields, name)
+			}
+			sort.Strings(fields)
+
+			return fmt.Errorf("unknown records field %q: must be one of %s", field, strings.Join(fields, ", "))
+		}
+		if field == "personnel-number" && o.PersonnelNumber == "" {
+			return errors.New("records.personnel-number is not configured")
+		}
+	}
+
+	return nil
+}
+
+func (o RecordsOptions) fields() []string {
+	if len(o.Fields) == 0 {
+		return defaultRecordFields
+	}
+
+	return o.Fields
+}
+
+// dateLayout converts a date format like "DD.MM.YYYY" to a Go time layout, the default is "YYYYMMDD".
+func (o RecordsOptions) dateLayout() string {
+	format := o.DateFormat
+	if format == "" {
+		format = "YYYYMMDD"
+	}
+
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(format)
+}
+
+// RecordsFormatter flattens the weekly grid into one record per employee, date and order.
+// Days without hours are skipped, rows without CATS ID can not be uploaded and fail the export.
+type RecordsFormatter struct {
+	Options RecordsOptions
+	Locale  Locale
+}
+
+func (f RecordsFormatter) Format(w io.Writer, report Report) error {
+	if err := f.Options.Validate(); err != nil {
+		return err
+	}
+
+	for _, catsEntry := range report.Entries {
+		if catsEntry.CatsID != "-" {
+			continue
+		}
+		for _, day := range report.Days {
+			if f.Locale.HoursFormat().Round(catsEntry.Durations[day.Format(dateFormat)].Hours()) != 0 {
+				return fmt.Errorf("hours without CATS ID on %s can not be uploaded, add the ID to the Clockify project name", day.Format(dateFormat))
+			}
+		}
+	}
+
+	separator := f.Options.Separator
+	if separator == "" {
+		separator = "\t"
+	}
+	fields := f.Options.fields()
+
+	if f.Options.Header {
+		header := make([]string, len(fields))
+		for i, field := range fields {
+			header[i] = recordHeaders[field]
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(header, separator)); err != nil {
+			return err
+		}
+	}
+
+	for _, day := range report.Days {
+		for _, catsEntry := range report.Entries {
+			hours := catsEntry.Durations[day.Format(dateFormat)].Hours()
+			if f.Locale.HoursFormat().Round(hours) == 0 {
+				continue
+			}
+
+			text, text2, textExternal := report.texts(catsEntry)
+			values := map[string]string{
+				"personnel-number": f.Options.PersonnelNumber,
+				"date":             day.Format(f.Options.dateLayout()),
+				"cats-id":          catsEntry.CatsID,
+				"category":         report.Category,
+				"hours":            f.Locale.FormatHours(hours),
+				"text":             text,
+				"text2":            text2,
+				"text-external":    textExternal,
+			}
+
+			record := make([]string, len(fields))
+			for i, field := range fields {
+				record[i] = values[field]
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(record, separator)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
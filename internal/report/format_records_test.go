@@ -0,0 +1,83 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordsFormatter_defaultFields(t *testing.T) {
+	report := makeFormatterReport()
+	second := emptyWeek(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	second["2022-01-03"] = 2 * time.Hour
+	report.Entries = append(report.Entries, CatsEntity{CatsID: "CATS-2", Durations: second})
+
+	var output strings.Builder
+	err := RecordsFormatter{Options: RecordsOptions{PersonnelNumber: "00012345"}}.Format(&output, report)
+	assert.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"00012345\t20220103\tCATS-1\tID\t1,50",
+		"00012345\t20220103\tCATS-2\tID\t2,00",
+		"00012345\t20220105\tCATS-1\tID\t8,00",
+		"",
+	}, "\n"), output.String(), "one record per date and order, days without hours are skipped")
+}
+
+func TestRecordsFormatter_configuredFields(t *testing.T) {
+	options := RecordsOptions{
+		DateFormat: "DD.MM.YYYY",
+		Fields:     []string{"date", "hours", "cats-id", "text"},
+		Separator:  ";",
+		Header:     true,
+	}
+
+	var output strings.Builder
+	assert.NoError(t, RecordsFormatter{Options: options}.Format(&output, makeFormatterReport()))
+
+	assert.Equal(t, strings.Join([]string{
+		"WORKDATE;CATSHOURS;RAUFNR;SHORTTEXT",
+		"03.01.2022;1,50;CATS-1;Task",
+		"05.01.2022;8,00;CATS-1;Task",
+		"",
+	}, "\n"), output.String())
+}
+
+func TestRecordsFormatter_rowWithoutCatsID(t *testing.T) {
+	report := makeFormatterReport()
+	missing := emptyWeek(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	missing["2022-01-04"] = time.Hour
+	report.Entries = append(report.Entries, CatsEntity{CatsID: "-", Durations: missing})
+
+	var output strings.Builder
+	err := RecordsFormatter{Options: RecordsOptions{PersonnelNumber: "00012345"}}.Format(&output, report)
+	assert.EqualError(t, err, "hours without CATS ID on 2022-01-04 can not be uploaded, add the ID to the Clockify project name")
+	assert.Empty(t, output.String())
+}
+
+func TestRecordsFormatter_skipsHoursRoundedToZero(t *testing.T) {
+	report := makeFormatterReport()
+	short := emptyWeek(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	short["2022-01-04"] = 20 * time.Second
+	report.Entries = append(report.Entries, CatsEntity{CatsID: "-", Durations: short})
+
+	var output strings.Builder
+	locale := Locale{}.WithHoursFormat(HoursMinutes)
+	err := RecordsFormatter{Options: RecordsOptions{PersonnelNumber: "00012345"}, Locale: locale}.Format(&output, report)
+	assert.NoError(t, err, "20 seconds are displayed as 0 minutes")
+
+	assert.Equal(t, strings.Join([]string{
+		"00012345\t20220103\tCATS-1\tID\t90",
+		"00012345\t20220105\tCATS-1\tID\t480",
+		"",
+	}, "\n"), output.String())
+}
+
+func TestRecordsOptions_Validate(t *testing.T) {
+	assert.EqualError(t, RecordsOptions{}.Validate(), "records.personnel-number is not configured")
+	assert.NoError(t, RecordsOptions{Fields: []string{"date", "hours"}}.Validate())
+	assert.EqualError(t, RecordsOptions{Fields: []string{"pernr"}}.Validate(),
+		`unknown records field "pernr": must be one of category, cats-id, date, hours, personnel-number, text, text-external, text2`)
+}
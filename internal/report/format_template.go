@@ -0,0 +1,197 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateOptions configures the user-defined Go text/template that renders the report.
+type TemplateOptions struct {
+	File string `mapstructure:"file"`
+}
+
+// TemplateData is the data model passed to user-defined templates.
+type TemplateData struct {
+	Year int
+	Week int
+	// Label names the weeks of the report, e.g. "2026-W36" or "2026-W36–2026-W40" for combined reports.
+	Label    string
+	From     time.Time
+	To       time.Time
+	Category string
+	WithText bool
+	// Days are the columns of the report, usually Monday to Sunday.
+	Days []TemplateDay
+	// Rows are the CATS rows in the order of the report.
+	Rows []TemplateRow
+	// Total is the reported time without absence rows.
+	Total     float64
+	Absence   float64
+	Target    float64
+	HasTarget bool
+	Warnings  []string
+}
+
+// TemplateDay is a column of the report with the totals of the day.
+type TemplateDay struct {
+	Date    time.Time
+	Total   float64
+	Absence float64
+	Target  float64
+	Holiday string
+}
+
+// TemplateRow is a CATS row, Hours holds one value per day of the report.
+type TemplateRow struct {
+	CatsID       string
+	Text         string
+	Text2        string
+	TextExternal string
+	Category     string
+	Absence      bool
+	Hours        []float64
+	Total        float64
+}
+
+// TemplateFormatter renders the report with a user-defined Go text/template file.
+// Besides the built-in functions, templates can use hours, signedHours, date, dayHeader, isoDate and join.
+type TemplateFormatter struct {
+	Options TemplateOptions
+	Locale  Locale
+}
+
+// Validate checks that the template file can be parsed and rendered for an example report,
+// so that unknown fields and functions are reported before generating a report.
+func (o TemplateOptions) Validate() error {
+	tmpl, err := o.parse(Locale{})
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(io.Discard, exampleTemplateData()); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	return nil
+}
+
+func (f TemplateFormatter) Format(w io.Writer, report Report) error {
+	tmpl, err := f.Options.parse(f.Locale)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, newTemplateData(report))
+}
+
+func (o TemplateOptions) parse(locale Locale) (*template.Template, error) {
+	if o.File == "" {
+		return nil, fmt.Errorf("format \"template\" requires a template file: set template.file in the config or pass --template")
+	}
+
+	content, err := os.ReadFile(o.File)
+	if err != nil {
+		return nil, fmt.Errorf("could not read template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(o.File)).
+		Option("missingkey=error").
+		Funcs(templateFuncs(locale)).
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+func templateFuncs(locale Locale) template.FuncMap {
+	return template.FuncMap{
+		"hours":       locale.FormatHours,
+		"signedHours": locale.FormatSignedHours,
+		"date":        locale.FormatDate,
+		"dayHeader":   locale.FormatDayHeader,
+		"isoDate":     func(date time.Time) string { return date.Format(dateFormat) },
+		"join":        strings.Join,
+	}
+}
+
+func newTemplateData(report Report) TemplateData {
+	data := TemplateData{
+		Year:      report.Year,
+		Week:      report.Week,
+		Label:     report.Label(),
+		Category:  report.Category,
+		WithText:  report.WithText,
+		Total:     report.Summary.Total,
+		Absence:   report.Summary.Absence,
+		Target:    report.Summary.Target,
+		HasTarget: report.Summary.HasTarget,
+		Warnings:  report.Summary.Warnings,
+	}
+	if len(report.Days) > 0 {
+		data.From = report.Days[0]
+		data.To = report.Days[len(report.Days)-1]
+	}
+
+	for _, day := range report.Days {
+		templateDay := TemplateDay{Date: day}
+		if summary, ok := report.Summary.day(day.Format(dateFormat)); ok {
+			templateDay.Absence = summary.Absence
+			templateDay.Target = summary.Target
+			templateDay.Holiday = summary.Holiday
+		}
+		data.Days = append(data.Days, templateDay)
+	}
+
+	for _, catsEntry := range report.Entries {
+		hours := report.hours(catsEntry)
+		text, text2, textExternal := report.texts(catsEntry)
+		data.Rows = append(data.Rows, TemplateRow{
+			CatsID:       catsEntry.CatsID,
+			Text:         text,
+			Text2:        text2,
+			TextExternal: textExternal,
+			Category:     report.Category,
+			Absence:      catsEntry.Absence,
+			Hours:        hours,
+			Total:        sum(hours),
+		})
+
+		if !catsEntry.Absence {
+			for i, h := range hours {
+				data.Days[i].Total += h
+			}
+		}
+	}
+
+	return data
+}
+
+// exampleTemplateData is a report with a row, a holiday and target hours to validate templates.
+func exampleTemplateData() TemplateData {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	durations := emptyWeek(start)
+	durations[start.Format(dateFormat)] = 8 * time.Hour
+
+	summary := Summary{Total: 8, Target: 8, HasTarget: true}
+	for i := 0; i < 7; i++ {
+		summary.Days = append(summary.Days, DaySummary{Date: start.AddDate(0, 0, i), Holiday: "Example"})
+	}
+
+	year, week := start.ISOWeek()
+	return newTemplateData(Report{
+		Year:     year,
+		Week:     week,
+		Days:     reportColumns(start, nil),
+		Category: "ID",
+		WithText: true,
+		Entries:  []CatsEntity{{CatsID: "CATS-1", Text: "Text", Durations: durations}},
+		Summary:  summary,
+	})
+}
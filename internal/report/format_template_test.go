@@ -0,0 +1,50 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTemplate(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "week.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	path := writeTemplate(t, `Week {{.Year}}-W{{.Week}} {{date .From}} - {{date .To}}
+{{range .Rows}}{{.CatsID}};{{.Text}};{{range .Hours}}{{hours .}};{{end}}{{hours .Total}}
+{{end}}{{range .Days}}{{if .Total}}{{isoDate .Date}}={{hours .Total}} {{end}}{{end}}
+Total {{hours .Total}}`)
+
+	output := formatReportWith(t, TemplateFormatter{Options: TemplateOptions{File: path}}, makeFormatterReport())
+
+	assert.Equal(t, "Week 2022-W1 03.01.2022 - 09.01.2022\n"+
+		"CATS-1;Task;1,50;0,00;8,00;0,00;0,00;0,00;0,00;9,50\n"+
+		"2022-01-03=1,50 2022-01-05=8,00 \n"+
+		"Total 9,50", output)
+}
+
+func TestTemplateOptions_Validate(t *testing.T) {
+	assert.NoError(t, TemplateOptions{File: writeTemplate(t, `{{range .Rows}}{{.CatsID}}{{end}}`)}.Validate())
+
+	err := TemplateOptions{}.Validate()
+	assert.EqualError(t, err, `format "template" requires a template file: set template.file in the config or pass --template`)
+
+	err = TemplateOptions{File: filepath.Join(t.TempDir(), "missing.tmpl")}.Validate()
+	assert.ErrorContains(t, err, "could not read template: open ")
+
+	err = TemplateOptions{File: writeTemplate(t, "{{range .Rows}}\n{{.CatsID}")}.Validate()
+	assert.EqualError(t, err, `invalid template: template: week.tmpl:2: bad character U+007D '}'`)
+
+	err = TemplateOptions{File: writeTemplate(t, "{{.Weeks}}")}.Validate()
+	assert.EqualError(t, err, `invalid template: template: week.tmpl:1:2: executing "week.tmpl" at <.Weeks>: can't evaluate field Weeks in type report.TemplateData`)
+
+	err = TemplateOptions{File: writeTemplate(t, "{{minutes .Total}}")}.Validate()
+	assert.True(t, strings.HasPrefix(err.Error(), `invalid template: template: week.tmpl:1: function "minutes" not defined`))
+}
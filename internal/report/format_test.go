@@ -0,0 +1,113 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeFormatterReport() Report {
+	start := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	durations := emptyWeek(start)
+	durations["2022-01-03"] = 90 * time.Minute
+	durations["2022-01-05"] = 8 * time.Hour
+
+	return Report{
+		Year:     2022,
+		Week:     1,
+		Days:     reportColumns(start, nil),
+		Category: "ID",
+		WithText: true,
+		Entries: []CatsEntity{
+			{CatsID: "CATS-1", Text: "Task", Text2: "Detail | more", Durations: durations},
+		},
+		Summary: Summary{Total: 9.5},
+	}
+}
+
+func formatReport(t *testing.T, format string, report Report) string {
+	formatter, err := NewFormatter(format, FormatOptions{})
+	assert.NoError(t, err)
+
+	return formatReportWith(t, formatter, report)
+}
+
+func formatReportWith(t *testing.T, formatter Formatter, report Report) string {
+	var output strings.Builder
+	assert.NoError(t, formatter.Format(&output, report))
+	return output.String()
+}
+
+func TestNewFormatter_unknownFormat(t *testing.T) {
+	_, err := NewFormatter("yaml", FormatOptions{})
+	assert.EqualError(t, err, `unknown format "yaml": must be one of csv, html, ics, json, markdown, records, template, tsv, xlsx`)
+}
+
+func TestFileExtension(t *testing.T) {
+	assert.Equal(t, ".md", FileExtension("Markdown"))
+	assert.Equal(t, ".xlsx", FileExtension("xlsx"))
+	assert.Equal(t, ".txt", FileExtension("records"))
+}
+
+func TestTSVFormatter(t *testing.T) {
+	output := formatReport(t, "tsv", makeFormatterReport())
+
+	assert.Equal(t, "CATS-1\t\tTask\tDetail | more\t\tID\t1,50\t\t0,00\t\t8,00\t\t0,00\t\t0,00\t\t0,00\t\t0,00\t\t\n", output)
+}
+
+func TestTSVFormatter_withoutText(t *testing.T) {
+	report := makeFormatterReport()
+	report.WithText = false
+
+	parts := strings.Split(formatReport(t, "tsv", report), "\t")
+	assert.Equal(t, "", parts[2])
+	assert.Equal(t, "", parts[3])
+}
+
+func TestCSVFormatter(t *testing.T) {
+	output := formatReport(t, "csv", makeFormatterReport())
+
+	assert.Equal(t, "Rec. order,Text,Text 2,Text External,Category,03.01.2022,04.01.2022,05.01.2022,06.01.2022,07.01.2022,08.01.2022,09.01.2022\n"+
+		`CATS-1,Task,Detail | more,,ID,"1,50","0,00","8,00","0,00","0,00","0,00","0,00"`+"\n", output)
+}
+
+func TestJSONFormatter(t *testing.T) {
+	output := formatReport(t, "json", makeFormatterReport())
+
+	assert.JSONEq(t, `{
+		"year": 2022,
+		"week": 1,
+		"category": "ID",
+		"withText": true,
+		"days": ["2022-01-03", "2022-01-04", "2022-01-05", "2022-01-06", "2022-01-07", "2022-01-08", "2022-01-09"],
+		"rows": [{
+			"catsId": "CATS-1",
+			"text": "Task",
+			"text2": "Detail | more",
+			"textExternal": "",
+			"hours": {"2022-01-03": 1.5, "2022-01-04": 0, "2022-01-05": 8, "2022-01-06": 0, "2022-01-07": 0, "2022-01-08": 0, "2022-01-09": 0}
+		}],
+		"total": 9.5
+	}`, output)
+}
+
+func TestMarkdownFormatter(t *testing.T) {
+	output := formatReport(t, "markdown", makeFormatterReport())
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "| Rec. order | Text | Text 2 | Text External | Category | Mo 03.01. | Di 04.01. | Mi 05.01. | Do 06.01. | Fr 07.01. | Sa 08.01. | So 09.01. |", lines[0])
+	assert.Equal(t, "| --- | --- | --- | --- | --- | ---: | ---: | ---: | ---: | ---: | ---: | ---: |", lines[1])
+	assert.Equal(t, `| CATS-1 | Task | Detail \| more |  | ID | 1,50 | 0,00 | 8,00 | 0,00 | 0,00 | 0,00 | 0,00 |`, lines[2])
+}
+
+func TestMarkdownFormatter_withoutText(t *testing.T) {
+	report := makeFormatterReport()
+	report.WithText = false
+
+	lines := strings.Split(formatReport(t, "markdown", report), "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "| Rec. order | Category | Mo 03.01."))
+	assert.True(t, strings.HasPrefix(lines[2], "| CATS-1 | ID | 1,50"))
+}
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TSVFormatter renders the tab-separated CATS grid that can be pasted into CATS.
+// Columns: Rec. order, Description (empty), Text, Text 2, Text External, Category and the hours per day,
+// each followed by an empty column.
+type TSVFormatter struct {
+	Locale Locale
+}
+
+func (f TSVFormatter) Format(w io.Writer, report Report) error {
+	catsMeta := "%s\t\t%s\t%s\t%s\t%s\t" // Rec. order, Description (empty), Text, Text 2, Text External, Category
+
+	for _, catsEntry := range report.Entries {
+		var values []interface{}
+		for _, hours := range report.hours(catsEntry) {
+			values = append(values, f.Locale.FormatHours(hours))
+		}
+
+		text, text2, textExternal := report.texts(catsEntry)
+		catsStart := fmt.Sprintf(catsMeta, catsEntry.CatsID, text, text2, textExternal, report.Category)
+		catsStart += strings.Repeat("%s\t\t", len(values))
+
+		if _, err := fmt.Fprintf(w, catsStart+"\n", values...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
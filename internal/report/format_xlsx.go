@@ -0,0 +1,251 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// XLSXColumn is a column of the Excel upload template. The field "days" expands to one column per day.
+type XLSXColumn struct {
+	Field  string `mapstructure:"field"`
+	Header string `mapstructure:"header"`
+}
+
+// XLSXOptions configures the layout of the Excel file to match the upload template.
+type XLSXOptions struct {
+	Sheet      string       `mapstructure:"sheet"`
+	Columns    []XLSXColumn `mapstructure:"columns"`
+	NoMetadata bool         `mapstructure:"no-metadata"`
+}
+
+var xlsxHeaders = map[string]string{
+	"cats-id":       "Rec. order",
+	"description":   "Description",
+	"text":          "Text",
+	"text2":         "Text 2",
+	"text-external": "Text External",
+	"category":      "Category",
+	"days":          "",
+	"total":         "Total",
+	"empty":         "",
+}
+
+var defaultXLSXColumns = []XLSXColumn{
+	{Field: "cats-id"},
+	{Field: "description"},
+	{Field: "text"},
+	{Field: "text2"},
+	{Field: "text-external"},
+	{Field: "category"},
+	{Field: "days"},
+}
+
+// Validate checks that all configured columns have a known field.
+func (o XLSXOptions) Validate() error {
+	for _, column := range o.Columns {
+		if _, ok := xlsxHeaders[column.Field]; !ok {
+			fields := make([]string, 0, len(xlsxHeaders))
+			for field := range xlsxHeaders {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+
+			return fmt.Errorf("unknown xlsx column field %q: must be one of %s", column.Field, strings.Join(fields, ", "))
+		}
+	}
+
+	return nil
+}
+
+// XLSXFormatter writes an Excel file with week metadata, a header row, one row per entry and a totals row.
+// Hours are written as numeric cells.
+type XLSXFormatter struct {
+	Options XLSXOptions
+	Locale  Locale
+}
+
+func (f XLSXFormatter) Format(w io.Writer, report Report) error {
+	if err := f.Options.Validate(); err != nil {
+		return err
+	}
+
+	columns := f.Options.Columns
+	if len(columns) == 0 {
+		columns = defaultXLSXColumns
+	}
+
+	sheet := f.Options.Sheet
+	if sheet == "" {
+		sheet = "CATS"
+	}
+
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		return err
+	}
+
+	hoursStyle, err := file.NewStyle(f.hoursStyle(false))
+	if err != nil {
+		return err
+	}
+	boldStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	totalStyle, err := file.NewStyle(f.hoursStyle(true))
+	if err != nil {
+		return err
+	}
+
+	rows := [][]interface{}{}
+	styles := []int{}
+	if !f.Options.NoMetadata {
+		rows = append(rows,
+			[]interface{}{"Week", report.Label()},
+			[]interface{}{"From", f.Locale.FormatDate(report.Days[0])},
+			[]interface{}{"To", f.Locale.FormatDate(report.Days[len(report.Days)-1])},
+			[]interface{}{},
+		)
+		styles = append(styles, 0, 0, 0, 0)
+	}
+
+	header := []interface{}{}
+	for _, column := range columns {
+		if column.Field == "days" {
+			for _, day := range report.Days {
+				header = append(header, f.Locale.FormatDate(day))
+			}
+			continue
+		}
+
+		title := column.Header
+		if title == "" {
+			title = xlsxHeaders[column.Field]
+		}
+		header = append(header, title)
+	}
+	rows = append(rows, header)
+	styles = append(styles, boldStyle)
+
+	dayTotals := make([]float64, len(report.Days))
+	for _, catsEntry := range report.Entries {
+		hours := report.hours(catsEntry)
+		rowTotal := 0.0
+		for i, h := range hours {
+			h = f.Locale.HoursFormat().Round(h)
+			rowTotal += h
+			if !catsEntry.Absence {
+				dayTotals[i] += h
+			}
+		}
+
+		text, text2, textExternal := report.texts(catsEntry)
+		rows = append(rows, xlsxRow(columns, map[string]interface{}{
+			"cats-id":       catsEntry.CatsID,
+			"text":          text,
+			"text2":         text2,
+			"text-external": textExternal,
+			"category":      report.Category,
+			"total":         f.hoursValue(rowTotal),
+		}, f.hoursValues(hours)))
+		styles = append(styles, hoursStyle)
+	}
+
+	totals := xlsxRow(columns, map[string]interface{}{"total": f.hoursValue(report.DisplayedTotal(f.Locale.HoursFormat()))}, f.hoursValues(dayTotals))
+	if _, text := totals[0].(string); text {
+		totals[0] = "Total"
+	}
+	rows = append(rows, totals)
+	styles = append(styles, totalStyle)
+
+	for i, values := range rows {
+		first, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow(sheet, first, &values); err != nil {
+			return err
+		}
+		if styles[i] == 0 || len(values) == 0 {
+			continue
+		}
+
+		last, _ := excelize.CoordinatesToCellName(len(values), i+1)
+		if err := file.SetCellStyle(sheet, first, last, styles[i]); err != nil {
+			return err
+		}
+	}
+
+	return file.Write(w)
+}
+
+// hoursStyle returns the number format of hour cells for the display mode: 0.00 for decimal hours,
+// [h]:mm for hours and minutes and 0 for minutes.
+func (f XLSXFormatter) hoursStyle(bold bool) *excelize.Style {
+	style := &excelize.Style{NumFmt: 2}
+	switch f.Locale.HoursFormat() {
+	case HoursClock:
+		format := "[h]:mm"
+		style = &excelize.Style{CustomNumFmt: &format}
+	case HoursMinutes:
+		style = &excelize.Style{NumFmt: 1}
+	}
+	if bold {
+		style.Font = &excelize.Font{Bold: true}
+	}
+
+	return style
+}
+
+// hoursValue converts hours to the numeric cell value of the display mode.
+// Excel stores durations as fractions of a day, which is why hours and minutes are divided by 24.
+func (f XLSXFormatter) hoursValue(hours float64) interface{} {
+	switch f.Locale.HoursFormat() {
+	case HoursClock:
+		return minutes(hours) / 60 / 24
+	case HoursMinutes:
+		return int(minutes(hours))
+	default:
+		return RoundHours(hours)
+	}
+}
+
+func (f XLSXFormatter) hoursValues(hours []float64) []interface{} {
+	values := make([]interface{}, len(hours))
+	for i, h := range hours {
+		values[i] = f.hoursValue(h)
+	}
+
+	return values
+}
+
+// xlsxRow orders the values of a row by the configured columns, fields without value are empty cells.
+func xlsxRow(columns []XLSXColumn, values map[string]interface{}, hours []interface{}) []interface{} {
+	row := []interface{}{}
+	for _, column := range columns {
+		if column.Field == "days" {
+			row = append(row, hours...)
+			continue
+		}
+
+		value, ok := values[column.Field]
+		if !ok {
+			value = ""
+		}
+		row = append(row, value)
+	}
+
+	return row
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+
+	return total
+}
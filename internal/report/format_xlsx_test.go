@@ -0,0 +1,84 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func formatXLSX(t *testing.T, options XLSXOptions, report Report) *excelize.File {
+	var output bytes.Buffer
+	assert.NoError(t, XLSXFormatter{Options: options}.Format(&output, report))
+
+	file, err := excelize.OpenReader(&output)
+	assert.NoError(t, err)
+	return file
+}
+
+func TestXLSXFormatter_defaultLayout(t *testing.T) {
+	file := formatXLSX(t, XLSXOptions{}, makeFormatterReport())
+	defer file.Close()
+
+	rows, err := file.GetRows("CATS")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Week", "2022-W01"}, rows[0])
+	assert.Equal(t, []string{"From", "03.01.2022"}, rows[1])
+	assert.Equal(t, []string{"To", "09.01.2022"}, rows[2])
+	assert.Equal(t, []string{"Rec. order", "Description", "Text", "Text 2", "Text External", "Category",
+		"03.01.2022", "04.01.2022", "05.01.2022", "06.01.2022", "07.01.2022", "08.01.2022", "09.01.2022"}, rows[4])
+	assert.Equal(t, "CATS-1", rows[5][0])
+	assert.Equal(t, "Task", rows[5][2])
+	assert.Equal(t, "1.50", rows[5][6])
+	assert.Equal(t, "Total", rows[6][0])
+	assert.Equal(t, "8.00", rows[6][8])
+
+	cellType, err := file.GetCellType("CATS", "G6")
+	assert.NoError(t, err)
+	assert.NotContains(t, []excelize.CellType{excelize.CellTypeSharedString, excelize.CellTypeInlineString}, cellType, "hours are numeric cells")
+}
+
+func TestXLSXFormatter_configuredColumns(t *testing.T) {
+	options := XLSXOptions{
+		Sheet:      "Upload",
+		NoMetadata: true,
+		Columns: []XLSXColumn{
+			{Field: "days"},
+			{Field: "cats-id", Header: "Order"},
+			{Field: "total"},
+		},
+	}
+	file := formatXLSX(t, options, makeFormatterReport())
+	defer file.Close()
+
+	rows, err := file.GetRows("Upload")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, []string{"03.01.2022", "04.01.2022", "05.01.2022", "06.01.2022", "07.01.2022", "08.01.2022", "09.01.2022", "Order", "Total"}, rows[0])
+	assert.Equal(t, []string{"1.50", "0", "8.00", "0", "0", "0", "0", "CATS-1", "9.50"}, rows[1])
+	assert.Equal(t, "1.50", rows[2][0], "totals row starts with a number, no label")
+	assert.Equal(t, "9.50", rows[2][8])
+}
+
+func TestXLSXOptions_Validate(t *testing.T) {
+	err := XLSXOptions{Columns: []XLSXColumn{{Field: "hours"}}}.Validate()
+	assert.EqualError(t, err, `unknown xlsx column field "hours": must be one of category, cats-id, days, description, empty, text, text-external, text2, total`)
+}
+
+func TestXLSXFormatter_hoursFormat(t *testing.T) {
+	options := XLSXOptions{NoMetadata: true, Columns: []XLSXColumn{{Field: "cats-id"}, {Field: "days"}, {Field: "total"}}}
+
+	var output bytes.Buffer
+	formatter := XLSXFormatter{Options: options, Locale: Locale{}.WithHoursFormat(HoursMinutes)}
+	assert.NoError(t, formatter.Format(&output, makeFormatterReport()))
+
+	file, err := excelize.OpenReader(&output)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows("CATS")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CATS-1", "90", "0", "480", "0", "0", "0", "0", "570"}, rows[1])
+	assert.Equal(t, []string{"Total", "90", "0", "480", "0", "0", "0", "0", "570"}, rows[2])
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// HoursFormat is the display mode of hours in reports and summary lines.
+type HoursFormat string
+
+const (
+	// HoursDecimal displays decimal hours rounded to two decimals, e.g. "7,50".
+	HoursDecimal HoursFormat = "decimal"
+	// HoursClock displays hours and minutes rounded to the minute, e.g. "7:30".
+	HoursClock HoursFormat = "hhmm"
+	// HoursMinutes displays industrial minutes rounded to the minute, e.g. "450".
+	HoursMinutes HoursFormat = "minutes"
+)
+
+var hoursFormats = []HoursFormat{HoursDecimal, HoursClock, HoursMinutes}
+
+// ParseHoursFormat returns the display mode with the given name, an empty name is decimal.
+func ParseHoursFormat(name string) (HoursFormat, error) {
+	if name == "" {
+		return HoursDecimal, nil
+	}
+
+	for _, format := range hoursFormats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+
+	names := make([]string, len(hoursFormats))
+	for i, format := range hoursFormats {
+		names[i] = string(format)
+	}
+
+	return "", fmt.Errorf("unknown hours format %q: must be one of %s", name, strings.Join(names, ", "))
+}
+
+// Round rounds hours to the precision that is displayed: two decimals or whole minutes.
+func (f HoursFormat) Round(hours float64) float64 {
+	if f == HoursClock || f == HoursMinutes {
+		return minutes(hours) / 60
+	}
+
+	return RoundHours(hours)
+}
+
+// Unit is appended to hours in the summary lines, e.g. "h" for "38,50h".
+func (f HoursFormat) Unit() string {
+	switch f {
+	case HoursClock:
+		return ""
+	case HoursMinutes:
+		return "min"
+	default:
+		return "h"
+	}
+}
+
+// minutes rounds hours to whole minutes, without negative zero.
+func minutes(hours float64) float64 {
+	if m := math.Round(hours * 60); m != 0 {
+		return m
+	}
+
+	return 0
+}
+
+// clock prints hours as H:MM, rounded to the minute.
+func clock(hours float64, signed bool) string {
+	total := int(minutes(hours))
+
+	sign := ""
+	if total < 0 {
+		sign = "-"
+		total = -total
+	} else if signed {
+		sign = "+"
+	}
+
+	return fmt.Sprintf("%s%d:%02d", sign, total/60, total%60)
+}
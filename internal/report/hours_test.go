@@ -0,0 +1,103 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseHoursFormat(t *testing.T) {
+	format, err := ParseHoursFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, HoursDecimal, format)
+
+	format, err = ParseHoursFormat("HHMM")
+	assert.NoError(t, err)
+	assert.Equal(t, HoursClock, format)
+
+	_, err = ParseHoursFormat("seconds")
+	assert.EqualError(t, err, `unknown hours format "seconds": must be one of decimal, hhmm, minutes`)
+}
+
+func TestHoursFormat_Round(t *testing.T) {
+	assert.Equal(t, 7.51, HoursDecimal.Round(7.5083))
+	assert.Equal(t, 7.5, HoursClock.Round(7.5083))
+	assert.Equal(t, 7.5, HoursMinutes.Round(7.5083))
+}
+
+func TestReport_DisplayedTotal(t *testing.T) {
+	durations := emptyWeek(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	durations["2022-01-03"] = 20*time.Minute + 20*time.Second
+	durations["2022-01-04"] = 20*time.Minute + 20*time.Second
+	absence := emptyWeek(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC))
+	absence["2022-01-05"] = 8 * time.Hour
+	report := Report{
+		Days:    reportDays("2022-01-03T00:00:00Z", Period{}),
+		Entries: []CatsEntity{{CatsID: "CATS-1", Durations: durations}, {CatsID: "ABS-1", Durations: absence, Absence: true}},
+	}
+
+	assert.Equal(t, 0.68, report.DisplayedTotal(HoursDecimal), "sum of 0,34 + 0,34")
+	assert.InDelta(t, 40.0/60, report.DisplayedTotal(HoursClock), 1e-9, "sum of 0:20 + 0:20, not 0:41")
+	assert.Equal(t, "0:40", Locale{hours: HoursClock}.FormatHours(report.DisplayedTotal(HoursClock)))
+
+	var output bytes.Buffer
+	options := XLSXOptions{NoMetadata: true, Columns: []XLSXColumn{{Field: "days"}, {Field: "total"}}}
+	assert.NoError(t, XLSXFormatter{Options: options, Locale: Locale{hours: HoursMinutes}}.Format(&output, report))
+	file, err := excelize.OpenReader(&output)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows("CATS")
+	assert.NoError(t, err)
+	assert.Equal(t, "40", rows[1][7], "xlsx row total of 20 + 20 minutes")
+	assert.Equal(t, []string{"20", "20", "0", "0", "0", "0", "0", "40"}, rows[3], "xlsx totals row without the absence row")
+}
+
+func TestLocale_hoursFormat(t *testing.T) {
+	tests := []struct {
+		format HoursFormat
+		hours  string
+		signed string
+		unit   string
+	}{
+		{HoursDecimal, "7,51", "-0,25", "7,51h"},
+		{HoursClock, "7:30", "-0:15", "7:30"},
+		{HoursMinutes, "450", "-15", "450min"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			locale := Locale{}.WithHoursFormat(test.format)
+
+			assert.Equal(t, test.hours, locale.FormatHours(7.5083))
+			assert.Equal(t, test.signed, locale.FormatSignedHours(-0.25))
+			assert.Equal(t, test.unit, locale.FormatHoursWithUnit(7.5083))
+		})
+	}
+}
+
+func TestLocale_hoursFormatMinutesWithoutGrouping(t *testing.T) {
+	for _, name := range []string{"de-DE", "en-US"} {
+		locale, err := NewLocale(name)
+		assert.NoError(t, err)
+		locale = locale.WithHoursFormat(HoursMinutes)
+
+		assert.Equal(t, "2400", locale.FormatHours(40), name)
+		assert.Equal(t, "+2400min", locale.FormatSignedHoursWithUnit(40), name)
+	}
+}
+
+func TestLocale_hoursFormatWithoutNegativeZero(t *testing.T) {
+	assert.Equal(t, "+0:00", Locale{}.WithHoursFormat(HoursClock).FormatSignedHours(-0.001))
+	assert.Equal(t, "+0", Locale{}.WithHoursFormat(HoursMinutes).FormatSignedHours(-0.001))
+}
+
+func TestMarkdownFormatter_hoursFormat(t *testing.T) {
+	formatter := MarkdownFormatter{Locale: Locale{}.WithHoursFormat(HoursClock)}
+
+	output := formatReportWith(t, formatter, makeFormatterReport())
+	assert.Contains(t, output, "| CATS-1 | Task | Detail \\| more |  | ID | 1:30 | 0:00 | 8:00 | 0:00 | 0:00 | 0:00 | 0:00 |")
+}
@@ -0,0 +1,161 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// catsIDPattern matches CATS receivers like order numbers "400012", WBS elements "P-1234.01" or "CATSID-1".
+var catsIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// LintIssue is a problem with the CATS IDs in the name of a Clockify project.
+type LintIssue struct {
+	ProjectID string `json:"projectId"`
+	Project   string `json:"project"`
+	Severity  string `json:"severity"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+}
+
+// LintProjects parses the project names like the report does and returns the issues sorted by project name:
+// missing or malformed CATS IDs, unbalanced parentheses, duplicate IDs, invalid weights and "*" markers mixed
+// with CATS IDs. A CATS ID used by several projects is only a warning.
+func LintProjects(projects []ClockifyProject) []LintIssue {
+	sorted := append([]ClockifyProject{}, projects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+	})
+
+	issues := []LintIssue{}
+	usedBy := map[string]string{}
+	for _, project := range sorted {
+		issue := func(severity string, code string, format string, args ...interface{}) {
+			issues = append(issues, LintIssue{
+				ProjectID: project.ID,
+				Project:   project.Name,
+				Severity:  severity,
+				Code:      code,
+				Message:   fmt.Sprintf(format, args...),
+			})
+		}
+
+		if !balancedParentheses(project.Name) {
+			issue(LintError, "unbalanced-parentheses", "unbalanced parentheses in the project name")
+			continue
+		}
+
+		catsIDs := ParseCatsIDs(project.Name)
+		if !strings.Contains(project.Name, "(") {
+			issue(LintError, "missing-id", `no CATS ID in parentheses, the entries are reported as "-"`)
+			continue
+		}
+
+		if len(catsIDs) > 1 && contains(catsIDs, "*") {
+			if catsIDs[0] == "*" {
+				issue(LintError, "ambiguous-shared", `"*" must be the only entry of a shared project, the CATS IDs %s are ignored`, strings.Join(catsIDs[1:], ", "))
+			} else {
+				issue(LintError, "ambiguous-shared", `"*" must be the only entry of a shared project, it is reported as CATS ID "*"`)
+			}
+			continue
+		}
+		if catsIDs[0] == "*" {
+			continue
+		}
+
+		seen := map[string]bool{}
+		weights := []float64{}
+		for _, catsID := range catsIDs {
+			catsID, weight, weighted := strings.Cut(catsID, ":")
+			if weighted {
+				value, ok := parseWeight(weight)
+				if !ok {
+					issue(LintError, "invalid-weight", "invalid weight %q of CATS ID %q: must be a positive percentage", weight, catsID)
+				} else {
+					weights = append(weights, value)
+				}
+			}
+			catsID = strings.TrimSpace(catsID)
+
+			if catsID == "" {
+				issue(LintError, "malformed-id", "empty CATS ID")
+				continue
+			}
+			if !catsIDPattern.MatchString(catsID) {
+				issue(LintError, "malformed-id", "malformed CATS ID %q", catsID)
+				continue
+			}
+			if seen[catsID] {
+				issue(LintWarning, "duplicate-id", "CATS ID %q is listed twice", catsID)
+				continue
+			}
+			seen[catsID] = true
+
+			if other, ok := usedBy[catsID]; ok {
+				issue(LintWarning, "duplicate-id", "CATS ID %q is also used by project %q", catsID, other)
+				continue
+			}
+			usedBy[catsID] = project.Name
+		}
+
+		if len(weights) > 0 && len(weights) == len(catsIDs) {
+			total := 0.0
+			for _, weight := range weights {
+				total += weight
+			}
+			if math.Abs(total-100) > 0.01 {
+				issue(LintError, "invalid-weight", "the weights add up to %g%%, not 100%%", total)
+			} else {
+				issue(LintError, "invalid-weight", "weights are not supported, the time is split equally and reported to the CATS IDs with the weight")
+			}
+		}
+	}
+
+	return issues
+}
+
+// parseWeight parses the weight of a CATS ID like "CATSID-1:60" or "CATSID-1:60%" as a positive percentage.
+func parseWeight(weight string) (float64, bool) {
+	value, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(weight), "%"), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) || value <= 0 {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// balancedParentheses reports whether every opening parenthesis of the name is closed.
+func balancedParentheses(name string) bool {
+	depth := 0
+	for _, r := range name {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+
+	return depth == 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintProjects(t *testing.T) {
+	projects := []ClockifyProject{
+		{ID: "1", Name: "Valid (CATSID-1, CATSID-2 (Name))"},
+		{ID: "2", Name: "Shared (*)"},
+		{ID: "3", Name: "Internal"},
+		{ID: "4", Name: "Broken (CATSID-3"},
+		{ID: "5", Name: "Mixed (*, CATSID-4)"},
+		{ID: "6", Name: "Trailing (CATSID-5, *)"},
+		{ID: "7", Name: "Spaces (CATS ID 6, )"},
+		{ID: "8", Name: "Twice (CATSID-7, CATSID-7)"},
+		{ID: "9", Name: "Another (CATSID-1)"},
+	}
+
+	issues := LintProjects(projects)
+
+	assert.Equal(t, []LintIssue{
+		{ProjectID: "4", Project: "Broken (CATSID-3", Severity: LintError, Code: "unbalanced-parentheses", Message: "unbalanced parentheses in the project name"},
+		{ProjectID: "3", Project: "Internal", Severity: LintError, Code: "missing-id", Message: `no CATS ID in parentheses, the entries are reported as "-"`},
+		{ProjectID: "5", Project: "Mixed (*, CATSID-4)", Severity: LintError, Code: "ambiguous-shared", Message: `"*" must be the only entry of a shared project, the CATS IDs CATSID-4 are ignored`},
+		{ProjectID: "7", Project: "Spaces (CATS ID 6, )", Severity: LintError, Code: "malformed-id", Message: `malformed CATS ID "CATS ID 6"`},
+		{ProjectID: "7", Project: "Spaces (CATS ID 6, )", Severity: LintError, Code: "malformed-id", Message: "empty CATS ID"},
+		{ProjectID: "6", Project: "Trailing (CATSID-5, *)", Severity: LintError, Code: "ambiguous-shared", Message: `"*" must be the only entry of a shared project, it is reported as CATS ID "*"`},
+		{ProjectID: "8", Project: "Twice (CATSID-7, CATSID-7)", Severity: LintWarning, Code: "duplicate-id", Message: `CATS ID "CATSID-7" is listed twice`},
+		{ProjectID: "1", Project: "Valid (CATSID-1, CATSID-2 (Name))", Severity: LintWarning, Code: "duplicate-id", Message: `CATS ID "CATSID-1" is also used by project "Another (CATSID-1)"`},
+	}, issues)
+}
+
+func TestLintProjects_noIssues(t *testing.T) {
+	issues := LintProjects([]ClockifyProject{{Name: "Project (400012)"}, {Name: "WBS (P-1234.01/2)"}, {Name: "Shared (*)"}})
+
+	assert.Empty(t, issues)
+}
+
+func TestLintProjects_weights(t *testing.T) {
+	issues := LintProjects([]ClockifyProject{
+		{ID: "1", Name: "Negative (CATSID-1:-20, CATSID-2:120)"},
+		{ID: "2", Name: "NaN (CATSID-3:NaN)"},
+		{ID: "3", Name: "Short (CATSID-4:60%, CATSID-5:30%)"},
+		{ID: "4", Name: "Split (CATSID-6:70, CATSID-7:30)"},
+		{ID: "5", Name: "Zero (CATSID-8:0)"},
+	})
+
+	assert.Equal(t, []LintIssue{
+		{ProjectID: "2", Project: "NaN (CATSID-3:NaN)", Severity: LintError, Code: "invalid-weight", Message: `invalid weight "NaN" of CATS ID "CATSID-3": must be a positive percentage`},
+		{ProjectID: "1", Project: "Negative (CATSID-1:-20, CATSID-2:120)", Severity: LintError, Code: "invalid-weight", Message: `invalid weight "-20" of CATS ID "CATSID-1": must be a positive percentage`},
+		{ProjectID: "3", Project: "Short (CATSID-4:60%, CATSID-5:30%)", Severity: LintError, Code: "invalid-weight", Message: "the weights add up to 90%, not 100%"},
+		{ProjectID: "4", Project: "Split (CATSID-6:70, CATSID-7:30)", Severity: LintError, Code: "invalid-weight", Message: "weights are not supported, the time is split equally and reported to the CATS IDs with the weight"},
+		{ProjectID: "5", Project: "Zero (CATSID-8:0)", Severity: LintError, Code: "invalid-weight", Message: `invalid weight "0" of CATS ID "CATSID-8": must be a positive percentage`},
+	}, issues)
+}
+
+func TestLintProjects_multipleGroups(t *testing.T) {
+	issues := LintProjects([]ClockifyProject{{Name: "Project (A) - Team (B)"}})
+
+	assert.Len(t, issues, 1)
+	assert.Equal(t, `malformed CATS ID "A) - Team"`, issues[0].Message)
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const defaultLocale = "de-DE"
+
+// Locale formats numbers and dates of a report, e.g. decimal commas for "de-DE" or decimal points for "en-US".
+// The zero value formats like "de-DE" with decimal hours.
+type Locale struct {
+	tag     language.Tag
+	printer *message.Printer
+	hours   HoursFormat
+}
+
+// dateStyle holds the Go time layouts of a locale for full dates and for the day of day headers.
+type dateStyle struct {
+	date      string
+	dayHeader string
+}
+
+var (
+	germanDates = dateStyle{date: "02.01.2006", dayHeader: "02.01."}
+	usDates     = dateStyle{date: "01/02/2006", dayHeader: "01/02"}
+	dayFirst    = dateStyle{date: "02/01/2006", dayHeader: "02/01"}
+	isoDates    = dateStyle{date: "2006-01-02", dayHeader: "01-02"}
+)
+
+// weekdayAbbreviations holds the short weekday names per language, starting with Sunday like time.Weekday.
+// Other languages use the English names.
+var weekdayAbbreviations = map[string][7]string{
+	"de": {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	"en": {"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	"es": {"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	"fr": {"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	"it": {"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	"nl": {"zo", "ma", "di", "wo", "do", "vr", "za"},
+	"pt": {"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+	"sv": {"sön", "mån", "tis", "ons", "tor", "fre", "lör"},
+}
+
+// NewLocale parses a BCP 47 language tag like "de-DE" or "en-US".
+func NewLocale(name string) (Locale, error) {
+	tag, err := language.Parse(name)
+	if err != nil {
+		return Locale{}, fmt.Errorf("invalid locale %q: must be a language tag like \"de-DE\" or \"en-US\"", name)
+	}
+
+	return Locale{tag: tag, printer: message.NewPrinter(tag)}, nil
+}
+
+// WithHoursFormat returns a copy of the locale that displays hours in the given mode.
+func (l Locale) WithHoursFormat(format HoursFormat) Locale {
+	l.hours = format
+
+	return l
+}
+
+// HoursFormat returns the display mode of hours.
+func (l Locale) HoursFormat() HoursFormat {
+	if l.hours == "" {
+		return HoursDecimal
+	}
+
+	return l.hours
+}
+
+func (l Locale) String() string {
+	if l.printer == nil {
+		return defaultLocale
+	}
+
+	return l.tag.String()
+}
+
+func (l Locale) messagePrinter() *message.Printer {
+	if l.printer == nil {
+		return message.NewPrinter(language.Make(defaultLocale))
+	}
+
+	return l.printer
+}
+
+// FormatHours prints hours in the display mode, decimal hours with two decimals and the decimal separator of the locale.
+func (l Locale) FormatHours(hours float64) string {
+	return l.formatHours(hours, false)
+}
+
+// FormatSignedHours prints hours like FormatHours but always with a sign, e.g. "+1,50" for "de-DE".
+func (l Locale) FormatSignedHours(hours float64) string {
+	return l.formatHours(hours, true)
+}
+
+// FormatHoursWithUnit prints hours like FormatHours followed by the unit of the display mode, e.g. "7,50h" or "7:30".
+func (l Locale) FormatHoursWithUnit(hours float64) string {
+	return l.FormatHours(hours) + l.HoursFormat().Unit()
+}
+
+// FormatSignedHoursWithUnit prints hours like FormatSignedHours followed by the unit of the display mode.
+func (l Locale) FormatSignedHoursWithUnit(hours float64) string {
+	return l.FormatSignedHours(hours) + l.HoursFormat().Unit()
+}
+
+func (l Locale) formatHours(hours float64, signed bool) string {
+	switch l.HoursFormat() {
+	case HoursClock:
+		return clock(hours, signed)
+	case HoursMinutes:
+		// minutes are plain integers, a grouping separator like "2.400" reads as a decimal
+		if signed {
+			return fmt.Sprintf("%+.0f", minutes(hours))
+		}
+		return fmt.Sprintf("%.0f", minutes(hours))
+	default:
+		if signed {
+			return l.messagePrinter().Sprintf("%+.2f", hours)
+		}
+		return l.messagePrinter().Sprintf("%.2f", hours)
+	}
+}
+
+// FormatDate prints a date in the short numeric format of the locale, e.g. "12.10.2026" for "de-DE".
+func (l Locale) FormatDate(date time.Time) string {
+	return date.Format(l.dates().date)
+}
+
+// FormatDayHeader prints the weekday and the day of a report column, e.g. "Mo 12.10." for "de-DE".
+func (l Locale) FormatDayHeader(date time.Time) string {
+	return l.FormatWeekday(date) + " " + date.Format(l.dates().dayHeader)
+}
+
+// FormatWeekday prints the short weekday name of a date in the language of the locale, e.g. "Mo" for "de-DE".
+func (l Locale) FormatWeekday(date time.Time) string {
+	base := "de"
+	if l.printer != nil {
+		tagBase, _ := l.tag.Base()
+		base = tagBase.String()
+	}
+
+	names, ok := weekdayAbbreviations[base]
+	if !ok {
+		names = weekdayAbbreviations["en"]
+	}
+
+	return names[date.Weekday()]
+}
+
+func (l Locale) dates() dateStyle {
+	if l.printer == nil {
+		return germanDates
+	}
+
+	base, _ := l.tag.Base()
+	region, _ := l.tag.Region()
+	switch {
+	case base.String() == "de":
+		return germanDates
+	case region.String() == "US":
+		return usDates
+	case base.String() == "en", base.String() == "fr", base.String() == "es", base.String() == "it", base.String() == "pt":
+		return dayFirst
+	default:
+		return isoDates
+	}
+}
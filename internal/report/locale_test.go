@@ -0,0 +1,68 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLocale_invalid(t *testing.T) {
+	_, err := NewLocale("not a locale")
+	assert.EqualError(t, err, `invalid locale "not a locale": must be a language tag like "de-DE" or "en-US"`)
+}
+
+func TestLocale_zeroValueIsGerman(t *testing.T) {
+	var locale Locale
+	date := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "de-DE", locale.String())
+	assert.Equal(t, "1.234,50", locale.FormatHours(1234.5))
+	assert.Equal(t, "+1,50", locale.FormatSignedHours(1.5))
+	assert.Equal(t, "-0,25", locale.FormatSignedHours(-0.25))
+	assert.Equal(t, "12.10.2026", locale.FormatDate(date))
+	assert.Equal(t, "Mo 12.10.", locale.FormatDayHeader(date))
+}
+
+func TestLocale(t *testing.T) {
+	date := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		hours     string
+		date      string
+		dayHeader string
+	}{
+		{"de-DE", "1.234,50", "12.10.2026", "Mo 12.10."},
+		{"de-CH", "1\u2019234.50", "12.10.2026", "Mo 12.10."},
+		{"en-US", "1,234.50", "10/12/2026", "Mon 10/12"},
+		{"en-GB", "1,234.50", "12/10/2026", "Mon 12/10"},
+		{"fr-FR", "1\u00a0234,50", "12/10/2026", "lun. 12/10"},
+		{"sv-SE", "1\u00a0234,50", "2026-10-12", "mån 10-12"},
+		{"ja-JP", "1,234.50", "2026-10-12", "Mon 10-12"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			locale, err := NewLocale(test.name)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.hours, locale.FormatHours(1234.5))
+			assert.Equal(t, test.date, locale.FormatDate(date))
+			assert.Equal(t, test.dayHeader, locale.FormatDayHeader(date))
+		})
+	}
+}
+
+func TestFormatter_locale(t *testing.T) {
+	locale, err := NewLocale("en-US")
+	assert.NoError(t, err)
+
+	formatter, err := NewFormatter("csv", FormatOptions{Locale: locale})
+	assert.NoError(t, err)
+
+	var output strings.Builder
+	assert.NoError(t, formatter.Format(&output, makeFormatterReport()))
+	assert.Equal(t, "Rec. order,Text,Text 2,Text External,Category,01/03/2022,01/04/2022,01/05/2022,01/06/2022,01/07/2022,01/08/2022,01/09/2022\n"+
+		"CATS-1,Task,Detail | more,,ID,1.50,0.00,8.00,0.00,0.00,0.00,0.00\n", output.String())
+}
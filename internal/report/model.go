@@ -3,6 +3,7 @@ package report
 import "time"
 
 type ClockifyTimeEntry struct {
+	ID           string `json:"id"`
 	Description  string `json:"description"`
 	TimeInterval struct {
 		Start    string `json:"start"`
@@ -12,7 +13,57 @@ type ClockifyTimeEntry struct {
 	Project struct {
 		Name string `json:"name"`
 	} `json:"project"`
-	Billable bool `json:"billable"`
+	Billable bool   `json:"billable"`
+	Type     string `json:"type"`
+	Tags     []struct {
+		Name string `json:"name"`
+	} `json:"tags"`
+}
+
+type ClockifyTimeOffRequest struct {
+	PolicyName string `json:"policyName"`
+	Status     struct {
+		StatusType string `json:"statusType"`
+	} `json:"status"`
+	TimeOffPeriod struct {
+		Period struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		} `json:"period"`
+		IsHalfDay bool `json:"isHalfDay"`
+	} `json:"timeOffPeriod"`
+	TimeUnit string `json:"timeUnit"`
+}
+
+type ClockifyProject struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ClientName string `json:"clientName"`
+	Billable   bool   `json:"billable"`
+	Archived   bool   `json:"archived"`
+}
+
+// ClockifyUser is the owner of the api key.
+type ClockifyUser struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	ActiveWorkspace string `json:"activeWorkspace"`
+	Settings        struct {
+		TimeZone string `json:"timeZone"`
+	} `json:"settings"`
+}
+
+type ClockifyWorkspace struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ReportedTimeEntry is a Clockify entry of a report with the CATS IDs resolved from its project,
+// "*" for shared entries and "-" for projects without CATS ID.
+type ReportedTimeEntry struct {
+	ClockifyTimeEntry
+	CatsIDs []string
 }
 
 type CatsEntity struct {
@@ -21,4 +72,62 @@ type CatsEntity struct {
 	Text2        string
 	TextExternal string
 	Durations    map[string]time.Duration
+	Absence      bool
+}
+
+type DaySummary struct {
+	Date    time.Time
+	Hours   float64
+	Absence float64
+	Target  float64
+	Holiday string
+}
+
+// Delta returns the difference between the reported hours including absences and the contracted hours of the day.
+func (d DaySummary) Delta() float64 {
+	return d.Hours + d.Absence - d.Target
+}
+
+type Summary struct {
+	Days       []DaySummary
+	Total      float64
+	Absence    float64
+	Target     float64
+	HasTarget  bool
+	Deductions []Deduction
+	// Excluded holds the hours of excluded entries by reason, "break" or the name of the excluding tag.
+	Excluded map[string]float64
+	Warnings []string
+}
+
+// Delta returns the difference between the reported hours including absences and the contracted hours of the week.
+func (s Summary) Delta() float64 {
+	return s.Total + s.Absence - s.Target
+}
+
+// Report is a generated report for one week, rendered by a Formatter.
+// Days holds the columns of the report, each entry has a duration for every day.
+// TimeEntries are the Clockify entries the report was generated from, without filtered and excluded entries.
+// Period is the date range the report is limited to, e.g. by the month boundary, and zero for a full week.
+type Report struct {
+	Year        int
+	Week        int
+	Days        []time.Time
+	Category    string
+	WithText    bool
+	Entries     []CatsEntity
+	Summary     Summary
+	TimeEntries []ReportedTimeEntry
+	Period      Period
+}
+
+// day returns the summary of a day (YYYY-MM-DD), days filtered out by the month boundary are not part of the summary.
+func (s Summary) day(date string) (DaySummary, bool) {
+	for _, day := range s.Days {
+		if day.Date.Format(dateFormat) == date {
+			return day, true
+		}
+	}
+
+	return DaySummary{}, false
 }
@@ -0,0 +1,66 @@
+package report
+
+import "time"
+
+// Period limits a report to the days between From and To, both inclusive. A zero value leaves that side open.
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether the day of the date is within the period.
+func (p Period) Contains(date time.Time) bool {
+	day := date.Format(dateFormat)
+	if !p.From.IsZero() && day < p.From.Format(dateFormat) {
+		return false
+	}
+	if !p.To.IsZero() && day > p.To.Format(dateFormat) {
+		return false
+	}
+
+	return true
+}
+
+// IsZero reports whether the period is open on both sides.
+func (p Period) IsZero() bool {
+	return p.From.IsZero() && p.To.IsZero()
+}
+
+// monthChangePeriod returns the month of a week spanning a month boundary that is kept:
+// "end" keeps the month the week starts in, "start" keeps the following month.
+func monthChangePeriod(startToDate time.Time, monthChange string) Period {
+	firstOfMonth := time.Date(startToDate.Year(), startToDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	switch monthChange {
+	case "end":
+		return Period{From: firstOfMonth, To: firstOfMonth.AddDate(0, 1, -1)}
+	case "start":
+		return Period{From: firstOfMonth.AddDate(0, 1, 0), To: firstOfMonth.AddDate(0, 2, -1)}
+	default:
+		return Period{}
+	}
+}
+
+// MonthBoundary returns the part of the week the report covers: "" for the whole week, "end" for the days
+// before and "start" for the days after a month boundary. It returns false for other parts of a week.
+func (r Report) MonthBoundary() (string, bool) {
+	startToDate := getFirstDayOfWeek(r.Year, r.Week)
+	days := reportDays(startToDate.Format(timeFormat), r.Period)
+
+	switch {
+	case len(days) == 7:
+		return "", true
+	case len(days) == 0:
+		return "", false
+	}
+
+	first, last := days[0], days[len(days)-1]
+	if first.Equal(startToDate) && last.AddDate(0, 0, 1).Day() == 1 {
+		return "end", true
+	}
+	if last.Equal(startToDate.AddDate(0, 0, 6)) && first.Day() == 1 {
+		return "start", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,46 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod_Contains(t *testing.T) {
+	period := Period{From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)}
+
+	assert.False(t, period.Contains(time.Date(2026, 8, 31, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, period.Contains(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, period.Contains(time.Date(2026, 9, 30, 18, 0, 0, 0, time.UTC)))
+	assert.False(t, period.Contains(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, Period{}.Contains(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestMonthChangePeriod(t *testing.T) {
+	start := time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, Period{From: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)}, monthChangePeriod(start, "end"))
+	assert.Equal(t, Period{From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)}, monthChangePeriod(start, "start"))
+	assert.True(t, monthChangePeriod(start, "").IsZero())
+}
+
+func TestReport_MonthBoundary(t *testing.T) {
+	tests := []struct {
+		period   Period
+		boundary string
+		ok       bool
+	}{
+		{Period{}, "", true},
+		{Period{From: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC)}, "end", true},
+		{Period{From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 9, 6, 0, 0, 0, 0, time.UTC)}, "start", true},
+		{Period{From: time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)}, "", false},
+		{Period{From: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}, "", false},
+	}
+
+	for _, test := range tests {
+		boundary, ok := Report{Year: 2026, Week: 36, Period: test.period}.MonthBoundary()
+		assert.Equal(t, test.boundary, boundary)
+		assert.Equal(t, test.ok, ok)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// SharedDistributed marks "*" projects, whose time is distributed across the billable entries.
+	SharedDistributed = "distributed"
+	// SharedReceiving marks billable projects, whose entries receive the time of the "*" projects.
+	SharedReceiving = "receiving"
+)
+
+// ProjectMapping describes how the report maps the entries of a Clockify project to CATS IDs.
+type ProjectMapping struct {
+	ProjectID string   `json:"projectId"`
+	Project   string   `json:"project"`
+	Client    string   `json:"client"`
+	Billable  bool     `json:"billable"`
+	CatsIDs   []string `json:"catsIds"`
+	// Weights holds the exact share of every CATS ID, the time of an entry is split equally.
+	Weights []float64 `json:"weights"`
+	// Shared is SharedDistributed, SharedReceiving or empty for non-billable and unmapped projects.
+	// Billable is only the default of the project, the billable flag of every entry decides.
+	Shared string `json:"shared"`
+	// Source is where the CATS IDs come from, only the project name is supported.
+	Source string `json:"source"`
+}
+
+// Mapped reports whether the project has valid CATS IDs or is shared.
+func (m ProjectMapping) Mapped() bool {
+	return !contains(m.CatsIDs, "-")
+}
+
+// MapProjects resolves the CATS IDs of the projects like the report does, sorted by project name.
+func MapProjects(projects []ClockifyProject) []ProjectMapping {
+	mappings := []ProjectMapping{}
+	for _, project := range projects {
+		catsIDs := ParseCatsIDs(project.Name)
+		mapping := ProjectMapping{
+			ProjectID: project.ID,
+			Project:   project.Name,
+			Client:    project.ClientName,
+			Billable:  project.Billable,
+			CatsIDs:   catsIDs,
+			Weights:   []float64{},
+			Source:    "name",
+		}
+
+		if catsIDs[0] == "*" {
+			mapping.Shared = SharedDistributed
+		} else {
+			for range catsIDs {
+				mapping.Weights = append(mapping.Weights, 1/float64(len(catsIDs)))
+			}
+			if project.Billable && mapping.Mapped() {
+				mapping.Shared = SharedReceiving
+			}
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	sort.SliceStable(mappings, func(i, j int) bool {
+		return strings.ToLower(mappings[i].Project) < strings.ToLower(mappings[j].Project)
+	})
+
+	return mappings
+}
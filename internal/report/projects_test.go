@@ -0,0 +1,27 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapProjects(t *testing.T) {
+	mappings := MapProjects([]ClockifyProject{
+		{ID: "2", Name: "Shared (*)", Billable: false},
+		{ID: "1", Name: "ACME Portal (CATSID-1, CATSID-2 (Name), CATSID-3)", ClientName: "ACME", Billable: true},
+		{ID: "3", Name: "Internal"},
+		{ID: "4", Name: "Billable without ID", Billable: true},
+	})
+
+	assert.Equal(t, []ProjectMapping{
+		{ProjectID: "1", Project: "ACME Portal (CATSID-1, CATSID-2 (Name), CATSID-3)", Client: "ACME", Billable: true, CatsIDs: []string{"CATSID-1", "CATSID-2", "CATSID-3"}, Weights: []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, Shared: SharedReceiving, Source: "name"},
+		{ProjectID: "4", Project: "Billable without ID", Billable: true, CatsIDs: []string{"-"}, Weights: []float64{1}, Source: "name"},
+		{ProjectID: "3", Project: "Internal", CatsIDs: []string{"-"}, Weights: []float64{1}, Source: "name"},
+		{ProjectID: "2", Project: "Shared (*)", CatsIDs: []string{"*"}, Weights: []float64{}, Shared: SharedDistributed, Source: "name"},
+	}, mappings)
+	assert.True(t, mappings[0].Mapped())
+	assert.False(t, mappings[1].Mapped())
+	assert.False(t, mappings[2].Mapped())
+	assert.True(t, mappings[3].Mapped())
+}
@@ -7,9 +7,6 @@ import (
 	"sort"
 	"strings"
 	"time"
-
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 )
 
 var (
@@ -18,53 +15,312 @@ var (
 )
 
 type ReporterInterface interface {
-	Generate(year int, week int, category string, withText bool, monthChange string) (string, float64, error)
+	Generate(year int, week int, category string, withText bool, monthChange string) (Report, error)
+	GenerateRange(from time.Time, to time.Time, category string, withText bool) ([]Report, error)
+}
+
+// HolidayCalendar looks up public holidays, see the holiday package for the built-in calendars.
+type HolidayCalendar interface {
+	Holiday(date time.Time) (string, bool)
 }
 
 type Reporter struct {
 	Repository           RepositoryInterface
 	DescriptionDelimiter string
+	Schedules            Schedules
+	Holidays             HolidayCalendar
+	HolidayCatsID        string
+	// AbsenceTypes maps lower-cased Clockify time off policy names to CATS absence types.
+	// Time off is only fetched if at least one policy is mapped.
+	AbsenceTypes map[string]string
+	Breaks       BreakRules
+	// ExcludedTags removes entries with one of these tags from the report, compared case-insensitively.
+	ExcludedTags []string
+}
+
+func (r Reporter) Generate(year int, week int, category string, withText bool, monthChange string) (Report, error) {
+	return r.generate(year, week, category, withText, monthChangePeriod(getFirstDayOfWeek(year, week), monthChange))
+}
+
+// GenerateRange generates a report for every ISO week between from and to (inclusive).
+// The weeks at the edges of the range only contain the days within the range.
+func (r Reporter) GenerateRange(from time.Time, to time.Time, category string, withText bool) ([]Report, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: %s is before %s", to.Format(dateFormat), from.Format(dateFormat))
+	}
+
+	reports := []Report{}
+	fromYear, fromWeek := from.ISOWeek()
+	for startToDate := getFirstDayOfWeek(fromYear, fromWeek); !startToDate.After(to); startToDate = startToDate.AddDate(0, 0, 7) {
+		period := Period{From: startToDate, To: startToDate.AddDate(0, 0, 6)}
+		if from.After(period.From) {
+			period.From = from
+		}
+		if to.Before(period.To) {
+			period.To = to
+		}
+
+		year, week := startToDate.ISOWeek()
+		report, err := r.generate(year, week, category, withText, period)
+		if err != nil {
+			return nil, fmt.Errorf("week %04d-W%02d: %w", year, week, err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
 }
 
-func (r Reporter) Generate(year int, week int, category string, withText bool, monthChange string) (string, float64, error) {
-	start := getFirstDayOfWeek(year, week).Format(timeFormat)
+func (r Reporter) generate(year int, week int, category string, withText bool, period Period) (Report, error) {
+	startToDate := getFirstDayOfWeek(year, week)
+	start := startToDate.Format(timeFormat)
 
 	timeEntries, err := r.Repository.FetchClockifyData(start)
 	if err != nil {
-		return "", 0, err
+		return Report{}, err
 	}
 
-	convertedTimeEntries, err := r.convertTimeEntries(start, timeEntries, withText, monthChange)
+	timeEntries, excluded := r.filterTimeEntries(timeEntries, period)
+	convertedTimeEntries, err := r.convertTimeEntries(start, timeEntries, withText)
 
 	if err != nil {
-		return "", 0, err
+		return Report{}, err
+	}
+
+	deductions, warnings := r.applyBreakRules(timeEntries, convertedTimeEntries)
+
+	holidayEntries, holidayWarnings := r.generateHolidayEntries(start, period)
+	convertedTimeEntries = append(convertedTimeEntries, holidayEntries...)
+	warnings = append(warnings, holidayWarnings...)
+
+	if len(r.AbsenceTypes) > 0 {
+		timeOffRequests, err := r.Repository.FetchClockifyTimeOff(start)
+		if err != nil {
+			return Report{}, err
+		}
+
+		timeOffEntries, timeOffWarnings := r.generateTimeOffEntries(start, timeOffRequests, period)
+		convertedTimeEntries = append(convertedTimeEntries, timeOffEntries...)
+		warnings = append(warnings, timeOffWarnings...)
 	}
 
-	report := r.generateCatsReportData(convertedTimeEntries, category, withText)
-	total := r.calculateTotalHours(convertedTimeEntries)
-	return report, total, nil
+	summary := r.summarize(start, convertedTimeEntries, period)
+	summary.Deductions = deductions
+	summary.Excluded = excluded
+	summary.Warnings = append(warnings, summary.Warnings...)
+
+	return Report{
+		Year:        year,
+		Week:        week,
+		Days:        reportColumns(startToDate, convertedTimeEntries),
+		Category:    category,
+		WithText:    withText,
+		Entries:     convertedTimeEntries,
+		Summary:     summary,
+		TimeEntries: r.resolveTimeEntries(timeEntries),
+		Period:      period,
+	}, nil
 }
 
-func (r Reporter) convertTimeEntries(start string, timeEntries []ClockifyTimeEntry, withText bool, monthChange string) ([]CatsEntity, error) {
-	startToDate, _ := time.Parse(timeFormat, start)
-	startMonth := startToDate.Month()
-	catsEntries := []CatsEntity{}
-	nonBillableCatsEntries := []CatsEntity{}
-	sharedEntries := []ClockifyTimeEntry{}
-	billableSum := 0.0
+// resolveTimeEntries annotates the time entries with their CATS IDs.
+func (r Reporter) resolveTimeEntries(timeEntries []ClockifyTimeEntry) []ReportedTimeEntry {
+	reported := make([]ReportedTimeEntry, len(timeEntries))
+	for i, timeEntry := range timeEntries {
+		reported[i] = ReportedTimeEntry{ClockifyTimeEntry: timeEntry, CatsIDs: r.getCatsIDs(timeEntry)}
+	}
+
+	return reported
+}
+
+// filterTimeEntries removes the entries outside of the period (e.g. filtered out by the month boundary),
+// break entries and entries with an excluded tag. The hours of break and tagged entries are returned by reason.
+func (r Reporter) filterTimeEntries(timeEntries []ClockifyTimeEntry, period Period) ([]ClockifyTimeEntry, map[string]float64) {
+	filtered := []ClockifyTimeEntry{}
+	excluded := map[string]float64{}
 
 	for _, timeEntry := range timeEntries {
 		startDate, _ := time.Parse(timeFormat, timeEntry.TimeInterval.Start)
-		cleanDuration := strings.ToLower(strings.Replace(timeEntry.TimeInterval.Duration, "PT", "", 1))
-		duration, _ := time.ParseDuration(cleanDuration)
+		if !period.Contains(startDate) {
+			continue
+		}
+
+		if reason, ok := r.exclusionReason(timeEntry); ok {
+			excluded[reason] += parseDuration(timeEntry.TimeInterval.Duration).Hours()
+			continue
+		}
+
+		filtered = append(filtered, timeEntry)
+	}
+
+	return filtered, excluded
+}
+
+func (r Reporter) exclusionReason(timeEntry ClockifyTimeEntry) (string, bool) {
+	if strings.EqualFold(timeEntry.Type, "BREAK") {
+		return "break", true
+	}
+
+	for _, tag := range timeEntry.Tags {
+		for _, excludedTag := range r.ExcludedTags {
+			if strings.EqualFold(strings.TrimSpace(tag.Name), strings.TrimSpace(excludedTag)) {
+				return excludedTag, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// reportDays returns the days of the week starting at start, without the days outside of the period.
+func reportDays(start string, period Period) []time.Time {
+	startToDate, _ := time.Parse(timeFormat, start)
+	days := []time.Time{}
+
+	for i := 0; i < 7; i++ {
+		date := startToDate.AddDate(0, 0, i)
+		if !period.Contains(date) {
+			continue
+		}
+		days = append(days, date)
+	}
+
+	return days
+}
+
+func (r Reporter) holiday(date time.Time) (string, bool) {
+	if r.Holidays == nil {
+		return "", false
+	}
+
+	return r.Holidays.Holiday(date)
+}
+
+// generateHolidayEntries creates an absence row with the contracted hours of every holiday in the week.
+// Rows are only created if a holiday CATS ID is configured.
+func (r Reporter) generateHolidayEntries(start string, period Period) ([]CatsEntity, []string) {
+	if r.HolidayCatsID == "" || r.Holidays == nil {
+		return nil, nil
+	}
+
+	warnings := []string{}
+	startToDate, _ := time.Parse(timeFormat, start)
+	entry := CatsEntity{CatsID: r.HolidayCatsID, Durations: emptyWeek(startToDate), Absence: true}
+	found := false
+
+	for _, date := range reportDays(start, period) {
+		name, ok := r.holiday(date)
+		if !ok {
+			continue
+		}
+
+		hours := r.Schedules.TargetHours(date)
+		if len(r.Schedules) == 0 {
+			warnings = append(warnings, fmt.Sprintf("No holiday row for %s (%s): target-hours are not configured", name, date.Format("Mon 2006-01-02")))
+			continue
+		}
+		if hours == 0 {
+			continue
+		}
+
+		entry.Durations[date.Format(dateFormat)] = time.Duration(hours * float64(time.Hour))
+		found = true
+	}
+
+	if !found {
+		return nil, warnings
+	}
+
+	return []CatsEntity{entry}, warnings
+}
+
+// generateTimeOffEntries creates absence rows for approved time off requests with a mapped policy.
+// Full and half days are reported with the contracted hours of the day, holidays are skipped.
+func (r Reporter) generateTimeOffEntries(start string, requests []ClockifyTimeOffRequest, period Period) ([]CatsEntity, []string) {
+	startToDate, _ := time.Parse(timeFormat, start)
+	catsEntries := []CatsEntity{}
+	warnings := []string{}
 
-		if monthChange == "end" && startMonth != startDate.Month() {
+	for _, request := range requests {
+		if request.Status.StatusType != "" && request.Status.StatusType != "APPROVED" {
 			continue
 		}
-		if monthChange == "start" && startDate.Month() == startMonth {
+
+		catsID, ok := r.AbsenceTypes[strings.ToLower(request.PolicyName)]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("Time off policy %q is not mapped to a CATS absence type (time-off.policies)", request.PolicyName))
 			continue
 		}
 
+		periodStart, _ := time.Parse(time.RFC3339, request.TimeOffPeriod.Period.Start)
+		periodEnd, _ := time.Parse(time.RFC3339, request.TimeOffPeriod.Period.End)
+
+		for _, date := range reportDays(start, period) {
+			day := date.Format(dateFormat)
+			if day < periodStart.Format(dateFormat) || day > periodEnd.Format(dateFormat) {
+				continue
+			}
+			if _, ok := r.holiday(date); ok {
+				continue
+			}
+
+			var hours float64
+			if request.TimeUnit == "HOURS" {
+				hours = hoursOnDay(periodStart, periodEnd, date)
+			} else if len(r.Schedules) == 0 {
+				warnings = append(warnings, fmt.Sprintf("No time off row for %s (%s): target-hours are not configured", request.PolicyName, date.Format("Mon 2006-01-02")))
+				continue
+			} else {
+				hours = r.Schedules.TargetHours(date)
+				if request.TimeOffPeriod.IsHalfDay {
+					hours /= 2
+				}
+			}
+			if hours <= 0 {
+				continue
+			}
+
+			index := r.findCatsEntryID(catsEntries, catsID, "", "", "")
+			if index == -1 {
+				catsEntries = append(catsEntries, CatsEntity{CatsID: catsID, Durations: emptyWeek(startToDate), Absence: true})
+				index = len(catsEntries) - 1
+			}
+			catsEntries[index].Durations[day] += time.Duration(hours * float64(time.Hour))
+		}
+	}
+
+	return catsEntries, warnings
+}
+
+// hoursOnDay returns the hours of the period between start and end that fall on the day of the date,
+// in the time zone of start.
+func hoursOnDay(start time.Time, end time.Time, date time.Time) float64 {
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, start.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	if start.Before(dayStart) {
+		start = dayStart
+	}
+	if end.After(dayEnd) {
+		end = dayEnd
+	}
+	if !end.After(start) {
+		return 0
+	}
+
+	return end.Sub(start).Hours()
+}
+
+func (r Reporter) convertTimeEntries(start string, timeEntries []ClockifyTimeEntry, withText bool) ([]CatsEntity, error) {
+	startToDate, _ := time.Parse(timeFormat, start)
+	catsEntries := []CatsEntity{}
+	nonBillableCatsEntries := []CatsEntity{}
+	sharedEntries := []ClockifyTimeEntry{}
+	billableSum := 0.0
+
+	for _, timeEntry := range timeEntries {
+		startDate, _ := time.Parse(timeFormat, timeEntry.TimeInterval.Start)
+		duration := parseDuration(timeEntry.TimeInterval.Duration)
+
 		catsIDs := r.getCatsIDs(timeEntry)
 
 		// Split entries into shared, billable and non-billable entries
@@ -100,9 +356,7 @@ func (r Reporter) distributeSharedEntriesToBillableEntries(sharedEntries []Clock
 	// already-inflated durations as proportion base, leading to over-distribution.
 	totalSharedHours := 0.0
 	for _, sharedTimeEntry := range sharedEntries {
-		cleanDuration := strings.ToLower(strings.Replace(sharedTimeEntry.TimeInterval.Duration, "PT", "", 1))
-		sharedDuration, _ := time.ParseDuration(cleanDuration)
-		totalSharedHours += sharedDuration.Hours()
+		totalSharedHours += parseDuration(sharedTimeEntry.TimeInterval.Duration).Hours()
 	}
 
 	for _, catsEntry := range catsEntries {
@@ -113,9 +367,20 @@ func (r Reporter) distributeSharedEntriesToBillableEntries(sharedEntries []Clock
 	}
 }
 
+// parseDuration parses ISO 8601 durations from Clockify, e.g. "PT1H30M".
+func parseDuration(isoDuration string) time.Duration {
+	cleanDuration := strings.ToLower(strings.Replace(isoDuration, "PT", "", 1))
+	duration, _ := time.ParseDuration(cleanDuration)
+
+	return duration
+}
+
 func (r Reporter) calculateTotalHours(catsEntries []CatsEntity) float64 {
 	total := 0.0
 	for _, entry := range catsEntries {
+		if entry.Absence {
+			continue
+		}
 		for _, d := range entry.Durations {
 			total += d.Hours()
 		}
@@ -123,6 +388,44 @@ func (r Reporter) calculateTotalHours(catsEntries []CatsEntity) float64 {
 	return total
 }
 
+// summarize calculates the reported and contracted hours per day of the week.
+// Days outside of the period, e.g. filtered out by the month boundary, are not part of the summary.
+// Holidays have no target hours and time tracked on a holiday produces a warning.
+// Absence rows don't count as reported hours, but time off is credited against the target hours.
+func (r Reporter) summarize(start string, catsEntries []CatsEntity, period Period) Summary {
+	summary := Summary{
+		Total:     r.calculateTotalHours(catsEntries),
+		HasTarget: len(r.Schedules) > 0,
+	}
+
+	for _, date := range reportDays(start, period) {
+		day := DaySummary{Date: date, Target: r.Schedules.TargetHours(date)}
+		for _, entry := range catsEntries {
+			if entry.Absence {
+				day.Absence += entry.Durations[date.Format(dateFormat)].Hours()
+			} else {
+				day.Hours += entry.Durations[date.Format(dateFormat)].Hours()
+			}
+		}
+
+		if name, ok := r.holiday(date); ok {
+			day.Holiday = name
+			day.Target = 0
+			day.Absence = 0 // holiday rows are no time off
+			if day.Hours > 0 {
+				summary.Warnings = append(summary.Warnings, fmt.Sprintf("%.2fh tracked on public holiday %s (%s)", day.Hours, name, date.Format("Mon 2006-01-02")))
+			}
+		}
+
+		summary.Absence += day.Absence
+
+		summary.Target += day.Target
+		summary.Days = append(summary.Days, day)
+	}
+
+	return summary
+}
+
 func (r Reporter) generateCATsEntriesFromTimeEntry(withText bool, timeEntry ClockifyTimeEntry, catsIDs []string, duration time.Duration, catsEntries []CatsEntity, startToDate time.Time, startDate time.Time) []CatsEntity {
 	text := []string{"", "", ""}
 	if withText {
@@ -140,15 +443,7 @@ func (r Reporter) generateCATsEntriesFromTimeEntry(withText bool, timeEntry Cloc
 				Text:         text[0],
 				Text2:        text[1],
 				TextExternal: text[2],
-				Durations: map[string]time.Duration{
-					startToDate.AddDate(0, 0, 0).Format("2006-01-02"): time.Duration(0), // Monday
-					startToDate.AddDate(0, 0, 1).Format("2006-01-02"): time.Duration(0), // Tuesday
-					startToDate.AddDate(0, 0, 2).Format("2006-01-02"): time.Duration(0), // Wednesday
-					startToDate.AddDate(0, 0, 3).Format("2006-01-02"): time.Duration(0), // Thursday
-					startToDate.AddDate(0, 0, 4).Format("2006-01-02"): time.Duration(0), // Friday
-					startToDate.AddDate(0, 0, 5).Format("2006-01-02"): time.Duration(0), // Saturday
-					startToDate.AddDate(0, 0, 6).Format("2006-01-02"): time.Duration(0), // Sunday
-				},
+				Durations:    emptyWeek(startToDate),
 			},
 			)
 			index = len(catsEntries) - 1
@@ -162,6 +457,41 @@ func (r Reporter) generateCATsEntriesFromTimeEntry(withText bool, timeEntry Cloc
 	return catsEntries
 }
 
+// reportColumns returns the sorted days of all entries, which are the days of the week
+// unless Clockify returned entries outside of the requested week.
+func reportColumns(startToDate time.Time, catsEntries []CatsEntity) []time.Time {
+	dateKeys := map[string]bool{}
+	for day := range emptyWeek(startToDate) {
+		dateKeys[day] = true
+	}
+	for _, catsEntry := range catsEntries {
+		for day := range catsEntry.Durations {
+			dateKeys[day] = true
+		}
+	}
+
+	days := make([]time.Time, 0, len(dateKeys))
+	for day := range dateKeys {
+		date, _ := time.Parse(dateFormat, day)
+		days = append(days, date)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	return days
+}
+
+func emptyWeek(startToDate time.Time) map[string]time.Duration {
+	return map[string]time.Duration{
+		startToDate.AddDate(0, 0, 0).Format("2006-01-02"): time.Duration(0), // Monday
+		startToDate.AddDate(0, 0, 1).Format("2006-01-02"): time.Duration(0), // Tuesday
+		startToDate.AddDate(0, 0, 2).Format("2006-01-02"): time.Duration(0), // Wednesday
+		startToDate.AddDate(0, 0, 3).Format("2006-01-02"): time.Duration(0), // Thursday
+		startToDate.AddDate(0, 0, 4).Format("2006-01-02"): time.Duration(0), // Friday
+		startToDate.AddDate(0, 0, 5).Format("2006-01-02"): time.Duration(0), // Saturday
+		startToDate.AddDate(0, 0, 6).Format("2006-01-02"): time.Duration(0), // Sunday
+	}
+}
+
 func (r Reporter) findCatsEntryID(catsEntries []CatsEntity, catsID string, text string, text2 string, textExternal string) int {
 	for i, catsEntry := range catsEntries {
 		if catsEntry.CatsID == catsID && catsEntry.Text == text && catsEntry.Text2 == text2 && catsEntry.TextExternal == textExternal {
@@ -173,11 +503,17 @@ func (r Reporter) findCatsEntryID(catsEntries []CatsEntity, catsID string, text
 }
 
 func (r Reporter) getCatsIDs(t ClockifyTimeEntry) []string {
+	return ParseCatsIDs(t.Project.Name)
+}
+
+// ParseCatsIDs returns the CATS IDs in the parentheses of a project name, "*" for shared projects
+// and "-" for projects without CATS ID.
+func ParseCatsIDs(projectName string) []string {
 	rg, _ := regexp.Compile("\\((.*)\\)")
 
 	// Get CATS IDs from project name
-	if t.Project.Name != "" {
-		match := rg.FindAllStringSubmatch(t.Project.Name, -1)
+	if projectName != "" {
+		match := rg.FindAllStringSubmatch(projectName, -1)
 		if len(match) > 0 {
 			parts := strings.Split(match[0][1], ",")
 			ids := make([]string, len(parts))
@@ -207,39 +543,3 @@ func (r Reporter) splitDescription(description string) []string {
 
 	return []string{"", strings.TrimSpace(parts[0]), ""}
 }
-
-func (r Reporter) generateCatsReportData(catsEntries []CatsEntity, category string, withText bool) string {
-	var output string
-	p := message.NewPrinter(language.Make("de-DE"))
-	catsMeta := "%s\t\t%s\t%s\t%s\t%s\t" // Rec. order, Description (empty), Text, Text 2, Text External, Category
-	text := ""
-	text2 := ""
-	textExternal := ""
-
-	for _, catsEntry := range catsEntries {
-		var values []interface{}
-
-		// we have to sort the dates because looping over a map is not guaranteed to be in order
-		dateKeys := make([]string, 0)
-		for k := range catsEntry.Durations {
-			dateKeys = append(dateKeys, k)
-		}
-		sort.Strings(dateKeys)
-
-		for _, date := range dateKeys {
-			values = append(values, p.Sprintf("%.2f", catsEntry.Durations[date].Hours()))
-		}
-		if withText {
-			text = catsEntry.Text
-			text2 = catsEntry.Text2
-			textExternal = catsEntry.TextExternal
-		}
-
-		catsStart := fmt.Sprintf(catsMeta, catsEntry.CatsID, text, text2, textExternal, category)
-		catsStart += strings.Repeat("%s\t\t", len(values))
-
-		output += fmt.Sprintf(catsStart, values...) + "\n"
-	}
-
-	return output
-}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,7 +52,7 @@ func TestReporter_Generate_withTwoTimeEntriesForTheSameProjectAndDescription(t *
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 
 	assert.Nil(t, err)
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -113,7 +114,7 @@ func TestReporter_Generate_withTwoTimeEntriesForTheSameProjectAndDifferentDescri
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 	assert.Nil(t, err)
 
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -184,7 +185,7 @@ func TestReporter_Generate_withTwoTimeEntriesForTheDifferentProjectAndDifferentD
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "CategoryChanged", false, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "CategoryChanged", false, "")
 	assert.Nil(t, err)
 
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -234,7 +235,7 @@ func TestReporter_Generate_withSharedTimeEntriesForTwoProjects(t *testing.T) {
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 	assert.Nil(t, err)
 
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -288,7 +289,7 @@ func TestReporter_Generate_withDescriptionBlockTimeEntry(t *testing.T) {
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 	assert.Nil(t, err)
 
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -388,7 +389,7 @@ func TestReporter_ShareBillableEntries(t *testing.T) {
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 	assert.Nil(t, err)
 
 	assert.NotEmpty(t, report, "Report should not be empty")
@@ -474,7 +475,7 @@ func TestReporter_ErrorOnShareBillableEntriesWhenNoBillableEntryIsGiven(t *testi
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", true, "")
 
 	assert.Equal(t, err.Error(), "No billable time entries found! Please distribute the shared time manually: https://app.clockify.me/timesheet")
 	assert.Empty(t, report, "Report should be empty")
@@ -507,7 +508,7 @@ func TestReporter_Generate_monthChangeEnd_keepsCurrentMonthEntries(t *testing.T)
 		Repository:           repositoryMock{data: makeWeek5Entries()},
 	}
 
-	report, _, err := reporter.Generate(2022, 5, "ID", false, "end")
+	report, _, err := generateTSV(reporter, 2022, 5, "ID", false, "end")
 	assert.Nil(t, err)
 
 	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
@@ -521,7 +522,7 @@ func TestReporter_Generate_monthChangeStart_keepsNewMonthEntries(t *testing.T) {
 		Repository:           repositoryMock{data: makeWeek5Entries()},
 	}
 
-	report, _, err := reporter.Generate(2022, 5, "ID", false, "start")
+	report, _, err := generateTSV(reporter, 2022, 5, "ID", false, "start")
 	assert.Nil(t, err)
 
 	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
@@ -547,7 +548,7 @@ func TestReporter_Generate_projectWithoutParentheses_usesDashAsCatsID(t *testing
 		}},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "ID", false, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "ID", false, "")
 	assert.Nil(t, err)
 	assert.NotEmpty(t, report)
 
@@ -573,7 +574,7 @@ func TestReporter_Generate_catsIDsWithNames_stripsNames(t *testing.T) {
 		}},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "ID", false, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "ID", false, "")
 	assert.Nil(t, err)
 	assert.NotEmpty(t, report)
 
@@ -589,6 +590,33 @@ func TestReporter_Generate_catsIDsWithNames_stripsNames(t *testing.T) {
 	assert.Equal(t, "1,00", parts1[6])
 }
 
+func TestReporter_Generate_resolvesCatsIDsOfTimeEntries(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			{
+				ID:          "64a1",
+				Description: "Task",
+				TimeInterval: struct {
+					Start    string `json:"start"`
+					End      string `json:"end"`
+					Duration string `json:"duration"`
+				}{Start: "2022-01-03T08:00:00.000Z", Duration: "PT2H"},
+				Project: struct {
+					Name string `json:"name"`
+				}{Name: "Project name (CATS-1 (Name1), CATS-2 (Name2))"},
+				Billable: true,
+			},
+		}},
+	}
+
+	report, err := reporter.Generate(2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+	assert.Len(t, report.TimeEntries, 1)
+	assert.Equal(t, "64a1", report.TimeEntries[0].ID)
+	assert.Equal(t, []string{"CATS-1", "CATS-2"}, report.TimeEntries[0].CatsIDs)
+}
+
 func TestReporter_Generate_withOneDelimiterInDescription(t *testing.T) {
 	reporter := Reporter{
 		DescriptionDelimiter: "#",
@@ -607,7 +635,7 @@ func TestReporter_Generate_withOneDelimiterInDescription(t *testing.T) {
 		}},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "ID", true, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "ID", true, "")
 	assert.Nil(t, err)
 
 	parts := strings.Split(strings.TrimRight(report, "\n"), "\t")
@@ -679,7 +707,7 @@ func TestReporter_ShareBillableEntries_MultipleSharedEntries(t *testing.T) {
 		},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "Category", false, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "Category", false, "")
 	assert.Nil(t, err)
 	assert.NotEmpty(t, report)
 
@@ -693,22 +721,235 @@ func TestReporter_ShareBillableEntries_MultipleSharedEntries(t *testing.T) {
 	assert.Equal(t, "12,00", parts[6], "total hours must equal billable + all shared (not over-distributed)")
 }
 
+// generateTSV generates a report and renders it in the default tsv format.
+func generateTSV(reporter Reporter, year int, week int, category string, withText bool, monthChange string) (string, Summary, error) {
+	report, err := reporter.Generate(year, week, category, withText, monthChange)
+	if err != nil {
+		return "", Summary{}, err
+	}
+
+	var output strings.Builder
+	err = TSVFormatter{}.Format(&output, report)
+	return output.String(), report.Summary, err
+}
+
 type repositoryMock struct {
-	data []ClockifyTimeEntry
-	err  error
+	data    []ClockifyTimeEntry
+	timeOff []ClockifyTimeOffRequest
+	err     error
 }
 
 func (r repositoryMock) FetchClockifyData(start string) ([]ClockifyTimeEntry, error) {
 	return r.data, r.err
 }
 
+func (r repositoryMock) FetchClockifyTimeOff(start string) ([]ClockifyTimeOffRequest, error) {
+	return r.timeOff, r.err
+}
+
 func TestReporter_Generate_propagatesFetchError(t *testing.T) {
 	reporter := Reporter{
 		DescriptionDelimiter: "#",
 		Repository:           repositoryMock{err: errors.New("network failure")},
 	}
 
-	report, _, err := reporter.Generate(2022, 1, "ID", false, "")
+	report, _, err := generateTSV(reporter, 2022, 1, "ID", false, "")
 	assert.EqualError(t, err, "network failure")
 	assert.Empty(t, report)
 }
+
+type holidayCalendarMock map[string]string
+
+func (h holidayCalendarMock) Holiday(date time.Time) (string, bool) {
+	name, ok := h[date.Format("2006-01-02")]
+	return name, ok
+}
+
+func TestReporter_Generate_holidayHasNoTargetAndWarnsAboutTrackedTime(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{data: makeWeek5Entries()},
+		Schedules:            Schedules{{Hours: map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 8}}},
+		Holidays:             holidayCalendarMock{"2022-01-31": "Company Day"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(strings.Split(strings.TrimRight(report, "\n"), "\n")), "no holiday row without CATS ID")
+	assert.Equal(t, "Company Day", summary.Days[0].Holiday)
+	assert.Equal(t, 0.0, summary.Days[0].Target)
+	assert.Equal(t, 32.0, summary.Target)
+	assert.Equal(t, []string{"1.00h tracked on public holiday Company Day (Mon 2022-01-31)"}, summary.Warnings)
+}
+
+func TestReporter_Generate_holidayRowsWithCatsID(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{},
+		Schedules:            Schedules{{Hours: map[string]float64{"mon": 8, "tue": 6}}},
+		Holidays:             holidayCalendarMock{"2022-02-01": "Holiday", "2022-02-05": "Saturday Holiday"},
+		HolidayCatsID:        "ABS-1",
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
+	assert.Equal(t, 1, len(entities))
+
+	parts := strings.Split(entities[0], "\t")
+	assert.Equal(t, "ABS-1", parts[0])
+	assert.Equal(t, "0,00", parts[6])
+	assert.Equal(t, "6,00", parts[8])
+	assert.Equal(t, "0,00", parts[16])
+
+	assert.Equal(t, 0.0, summary.Total, "holiday rows are no reported hours")
+	assert.Equal(t, 8.0, summary.Target)
+	assert.Empty(t, summary.Warnings)
+}
+
+func TestReporter_Generate_holidayRowsWithoutTargetHoursWarns(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{},
+		Holidays:             holidayCalendarMock{"2022-02-01": "Holiday"},
+		HolidayCatsID:        "ABS-1",
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+	assert.Empty(t, report)
+	assert.Equal(t, []string{"No holiday row for Holiday (Tue 2022-02-01): target-hours are not configured"}, summary.Warnings)
+}
+
+func makeTimeOffRequest(policy, start, end string, halfDay bool) ClockifyTimeOffRequest {
+	request := ClockifyTimeOffRequest{PolicyName: policy, TimeUnit: "DAYS"}
+	request.Status.StatusType = "APPROVED"
+	request.TimeOffPeriod.Period.Start = start
+	request.TimeOffPeriod.Period.End = end
+	request.TimeOffPeriod.IsHalfDay = halfDay
+	return request
+}
+
+func TestReporter_Generate_timeOffRows(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{timeOff: []ClockifyTimeOffRequest{
+			makeTimeOffRequest("Vacation", "2022-01-31T00:00:00Z", "2022-02-06T23:59:59Z", false),
+			makeTimeOffRequest("Sick", "2022-02-04T00:00:00Z", "2022-02-04T23:59:59Z", true),
+			makeTimeOffRequest("Parental leave", "2022-02-03T00:00:00Z", "2022-02-03T23:59:59Z", false),
+		}},
+		Schedules:    Schedules{{Hours: map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 8}}},
+		Holidays:     holidayCalendarMock{"2022-02-01": "Holiday"},
+		AbsenceTypes: map[string]string{"vacation": "ABS-100", "sick": "ABS-200"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	entities := strings.Split(strings.TrimRight(report, "\n"), "\n")
+	assert.Equal(t, 2, len(entities))
+
+	vacation := strings.Split(entities[0], "\t")
+	assert.Equal(t, "ABS-100", vacation[0])
+	assert.Equal(t, []string{"8,00", "0,00", "8,00", "8,00", "8,00", "0,00", "0,00"},
+		[]string{vacation[6], vacation[8], vacation[10], vacation[12], vacation[14], vacation[16], vacation[18]},
+		"holiday and weekend have no time off hours")
+
+	sickParts := strings.Split(entities[1], "\t")
+	assert.Equal(t, "ABS-200", sickParts[0])
+	assert.Equal(t, "4,00", sickParts[14])
+
+	assert.Equal(t, 0.0, summary.Total)
+	assert.Equal(t, 36.0, summary.Absence)
+	assert.Equal(t, 32.0, summary.Target)
+	assert.Equal(t, []string{`Time off policy "Parental leave" is not mapped to a CATS absence type (time-off.policies)`}, summary.Warnings)
+}
+
+func TestReporter_Generate_timeOffNotFetchedWithoutAbsenceTypes(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{timeOff: []ClockifyTimeOffRequest{
+			makeTimeOffRequest("Vacation", "2022-01-31T00:00:00Z", "2022-02-06T23:59:59Z", false),
+		}},
+		Schedules: Schedules{{Hours: map[string]float64{"mon": 8}}},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+	assert.Empty(t, report)
+	assert.Equal(t, 0.0, summary.Absence)
+}
+
+func TestReporter_Generate_timeOffInHours(t *testing.T) {
+	doctor := makeTimeOffRequest("Doctor", "2022-02-02T08:00:00Z", "2022-02-02T10:30:00Z", false)
+	doctor.TimeUnit = "HOURS"
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{timeOff: []ClockifyTimeOffRequest{doctor}},
+		AbsenceTypes:         map[string]string{"doctor": "ABS-300"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	parts := strings.Split(strings.TrimRight(report, "\n"), "\t")
+	assert.Equal(t, "ABS-300", parts[0])
+	assert.Equal(t, "2,50", parts[10])
+	assert.Equal(t, 2.5, summary.Absence)
+}
+
+func TestReporter_Generate_timeOffInHoursSpanningTwoDays(t *testing.T) {
+	night := makeTimeOffRequest("Doctor", "2022-02-02T22:00:00Z", "2022-02-03T01:30:00Z", false)
+	night.TimeUnit = "HOURS"
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{timeOff: []ClockifyTimeOffRequest{night}},
+		AbsenceTypes:         map[string]string{"doctor": "ABS-300"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	parts := strings.Split(strings.TrimRight(report, "\n"), "\t")
+	assert.Equal(t, "2,00", parts[10], "Wednesday until midnight")
+	assert.Equal(t, "1,50", parts[12], "Thursday after midnight")
+	assert.Equal(t, 3.5, summary.Absence)
+}
+
+func TestReporter_Generate_excludesBreakAndTaggedEntries(t *testing.T) {
+	makeEntry := func(start, duration, entryType string, tags ...string) ClockifyTimeEntry {
+		entry := ClockifyTimeEntry{Description: "Task", Type: entryType}
+		entry.TimeInterval.Start = start
+		entry.TimeInterval.Duration = duration
+		entry.Project.Name = "Project (123)"
+		for _, tag := range tags {
+			entry.Tags = append(entry.Tags, struct {
+				Name string `json:"name"`
+			}{Name: tag})
+		}
+		return entry
+	}
+
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository: repositoryMock{data: []ClockifyTimeEntry{
+			makeEntry("2022-01-03T08:00:00Z", "PT4H", "REGULAR", "customer"),
+			makeEntry("2022-01-03T12:00:00Z", "PT30M", "BREAK"),
+			makeEntry("2022-01-03T12:30:00Z", "PT1H", "REGULAR", "No-Cats"),
+			makeEntry("2022-01-04T12:30:00Z", "PT15M", "", "private"),
+		}},
+		ExcludedTags: []string{"no-cats", "private"},
+	}
+
+	report, summary, err := generateTSV(reporter, 2022, 1, "ID", false, "")
+	assert.Nil(t, err)
+
+	parts := strings.Split(strings.TrimRight(report, "\n"), "\t")
+	assert.Equal(t, "4,00", parts[6])
+	assert.Equal(t, "0,00", parts[8])
+
+	assert.Equal(t, 4.0, summary.Total)
+	assert.Equal(t, map[string]float64{"break": 0.5, "no-cats": 1, "private": 0.25}, summary.Excluded)
+}
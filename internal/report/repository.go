@@ -1,6 +1,7 @@
 package report
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,8 +9,27 @@ import (
 	"time"
 )
 
+// projectPageSize is the largest page size of the Clockify projects endpoint.
+const projectPageSize = 5000
+
 type RepositoryInterface interface {
 	FetchClockifyData(start string) ([]ClockifyTimeEntry, error)
+	FetchClockifyTimeOff(start string) ([]ClockifyTimeOffRequest, error)
+}
+
+// ProjectRepositoryInterface fetches the projects of the workspace, e.g. to check their CATS IDs.
+type ProjectRepositoryInterface interface {
+	FetchClockifyProjects() ([]ClockifyProject, error)
+}
+
+// StatusError is returned for unsuccessful responses of the Clockify API.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("Clockify API error: %s", e.Status)
 }
 
 type Repository struct {
@@ -17,6 +37,7 @@ type Repository struct {
 	UserID      string
 	ApiKey      string
 	BaseURL     string
+	PTOBaseURL  string
 	HTTPClient  *http.Client
 }
 
@@ -47,7 +68,7 @@ func (r Repository) FetchClockifyData(start string) ([]ClockifyTimeEntry, error)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Clockify API error: %s", resp.Status)
+		return nil, StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -62,3 +83,139 @@ func (r Repository) FetchClockifyData(start string) ([]ClockifyTimeEntry, error)
 
 	return timeEntries, nil
 }
+
+// FetchClockifyTimeOff fetches the approved time off requests of the user overlapping the week starting at start.
+func (r Repository) FetchClockifyTimeOff(start string) ([]ClockifyTimeOffRequest, error) {
+	startDate, _ := time.Parse(timeFormat, start)
+	endDate := startDate.AddDate(0, 0, 7).Add(-time.Second)
+
+	baseURL := r.PTOBaseURL
+	if baseURL == "" {
+		baseURL = "https://pto.api.clockify.me"
+	}
+
+	url := fmt.Sprintf("%s/v1/workspaces/%s/requests", baseURL, r.WorkspaceID)
+
+	client := r.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	filter, _ := json.Marshal(map[string]interface{}{
+		"start":    startDate.Format(timeFormat),
+		"end":      endDate.Format(timeFormat),
+		"statuses": []string{"APPROVED"},
+		"users":    []string{r.UserID},
+		"page":     1,
+		"pageSize": 200,
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(filter))
+	req.Header.Add("X-Api-Key", r.ApiKey)
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Clockify time off API error: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Requests []ClockifyTimeOffRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error parsing Clockify time off response: %w", err)
+	}
+
+	return result.Requests, nil
+}
+
+// FetchClockifyProjects fetches all projects of the workspace that are not archived.
+func (r Repository) FetchClockifyProjects() ([]ClockifyProject, error) {
+	projects := []ClockifyProject{}
+	for page := 1; ; page++ {
+		var result []ClockifyProject
+		path := fmt.Sprintf("/api/v1/workspaces/%s/projects?archived=false&page=%d&page-size=%d", r.WorkspaceID, page, projectPageSize)
+		if err := r.fetchJSON(path, &result); err != nil {
+			return nil, err
+		}
+		projects = append(projects, result...)
+
+		if len(result) < projectPageSize {
+			return projects, nil
+		}
+	}
+}
+
+// FetchClockifyUser fetches the owner of the api key, which also verifies the api key.
+func (r Repository) FetchClockifyUser() (ClockifyUser, error) {
+	var user ClockifyUser
+	err := r.fetchJSON("/api/v1/user", &user)
+
+	return user, err
+}
+
+// FetchClockifyWorkspaces fetches the workspaces of the owner of the api key.
+func (r Repository) FetchClockifyWorkspaces() ([]ClockifyWorkspace, error) {
+	var workspaces []ClockifyWorkspace
+	err := r.fetchJSON("/api/v1/workspaces", &workspaces)
+
+	return workspaces, err
+}
+
+// FindWorkspace returns the workspace with the given ID.
+func FindWorkspace(workspaces []ClockifyWorkspace, id string) (ClockifyWorkspace, bool) {
+	for _, workspace := range workspaces {
+		if workspace.ID == id {
+			return workspace, true
+		}
+	}
+
+	return ClockifyWorkspace{}, false
+}
+
+// fetchJSON sends a GET request to the Clockify API and decodes the JSON response into v.
+func (r Repository) fetchJSON(path string, v interface{}) error {
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.clockify.me"
+	}
+
+	client := r.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, baseURL+path, nil)
+	req.Header.Add("X-Api-Key", r.ApiKey)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error parsing Clockify response: %w", err)
+	}
+
+	return nil
+}
@@ -120,3 +120,131 @@ func TestRepository_FetchClockifyData_invalidJSON(t *testing.T) {
 
 	assert.ErrorContains(t, err, "error parsing Clockify response")
 }
+
+func TestRepository_FetchClockifyTimeOff_success(t *testing.T) {
+	var capturedPath, capturedMethod string
+	var capturedFilter map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		capturedMethod = r.Method
+		json.NewDecoder(r.Body).Decode(&capturedFilter)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count":1,"requests":[{"policyName":"Vacation","status":{"statusType":"APPROVED"},"timeOffPeriod":{"period":{"start":"2022-01-03T00:00:00Z","end":"2022-01-04T23:59:59Z"},"isHalfDay":false},"timeUnit":"DAYS"}]}`))
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	repo.PTOBaseURL = server.URL
+	result, err := repo.FetchClockifyTimeOff("2022-01-03T00:00:00.000Z")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, capturedMethod)
+	assert.Equal(t, "/v1/workspaces/ws1/requests", capturedPath)
+	assert.Equal(t, []interface{}{"APPROVED"}, capturedFilter["statuses"])
+	assert.Equal(t, []interface{}{"user1"}, capturedFilter["users"])
+	assert.Equal(t, "2022-01-09T23:59:59Z", capturedFilter["end"])
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Vacation", result[0].PolicyName)
+	assert.Equal(t, "2022-01-04T23:59:59Z", result[0].TimeOffPeriod.Period.End)
+}
+
+func TestRepository_FetchClockifyTimeOff_nonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	repo.PTOBaseURL = server.URL
+	_, err := repo.FetchClockifyTimeOff("2022-01-03T00:00:00.000Z")
+
+	assert.EqualError(t, err, "Clockify time off API error: 403 Forbidden")
+}
+
+func TestRepository_FetchClockifyProjects_success(t *testing.T) {
+	var capturedURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedURL = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"p1","name":"Project (123)","clientName":"ACME","billable":true,"archived":false}]`))
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	result, err := repo.FetchClockifyProjects()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ClockifyProject{{ID: "p1", Name: "Project (123)", ClientName: "ACME", Billable: true}}, result)
+	assert.Equal(t, "/api/v1/workspaces/ws1/projects?archived=false&page=1&page-size=5000", capturedURL)
+}
+
+func TestRepository_FetchClockifyProjects_apiError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	_, err := repo.FetchClockifyProjects()
+
+	assert.EqualError(t, err, "Clockify API error: 401 Unauthorized")
+}
+
+func TestRepository_FetchClockifyUser_success(t *testing.T) {
+	var capturedPath, capturedKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		capturedKey = r.Header.Get("X-Api-Key")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"user1","name":"Jane","email":"jane@example.com","activeWorkspace":"ws1","settings":{"timeZone":"Europe/Berlin"}}`))
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	user, err := repo.FetchClockifyUser()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/user", capturedPath)
+	assert.Equal(t, "test-api-key", capturedKey)
+	assert.Equal(t, "user1", user.ID)
+	assert.Equal(t, "Europe/Berlin", user.Settings.TimeZone)
+}
+
+func TestRepository_FetchClockifyUser_unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	_, err := repo.FetchClockifyUser()
+
+	assert.Equal(t, StatusError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}, err)
+	assert.EqualError(t, err, "Clockify API error: 401 Unauthorized")
+}
+
+func TestRepository_FetchClockifyWorkspaces_success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id":"ws1","name":"ACME"},{"id":"ws2","name":"Private"}]`))
+	}))
+	defer server.Close()
+
+	repo := makeTestRepository(server)
+	workspaces, err := repo.FetchClockifyWorkspaces()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ClockifyWorkspace{{ID: "ws1", Name: "ACME"}, {ID: "ws2", Name: "Private"}}, workspaces)
+}
+
+func TestFindWorkspace(t *testing.T) {
+	workspaces := []ClockifyWorkspace{{ID: "ws1", Name: "ACME"}, {ID: "ws2", Name: "Private"}}
+
+	workspace, ok := FindWorkspace(workspaces, "ws2")
+	assert.True(t, ok)
+	assert.Equal(t, "Private", workspace.Name)
+
+	_, ok = FindWorkspace(workspaces, "ws3")
+	assert.False(t, ok)
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Schedule describes the contracted target hours per weekday for a period of time.
+// ValidFrom and ValidUntil are optional dates (YYYY-MM-DD); an empty value leaves the period open.
+type Schedule struct {
+	ValidFrom  string             `mapstructure:"valid-from"`
+	ValidUntil string             `mapstructure:"valid-until"`
+	Hours      map[string]float64 `mapstructure:"hours"`
+}
+
+// Schedules is a list of work schedules, e.g. a full-time contract followed by a part-time one.
+type Schedules []Schedule
+
+var weekdayNames = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+
+	weekday, ok := weekdayNames[name[:3]]
+	return weekday, ok
+}
+
+// Validate checks that all dates and weekday names of the schedules can be parsed
+// and that no weekday is given twice, e.g. as "mon" and "monday".
+func (s Schedules) Validate() error {
+	for i, schedule := range s {
+		from, until, err := schedule.period()
+		if err != nil {
+			return fmt.Errorf("invalid target hours schedule %d: %w", i+1, err)
+		}
+		if !from.IsZero() && !until.IsZero() && until.Before(from) {
+			return fmt.Errorf("invalid target hours schedule %d: valid-until %s is before valid-from %s", i+1, schedule.ValidUntil, schedule.ValidFrom)
+		}
+
+		names := make([]string, 0, len(schedule.Hours))
+		for name := range schedule.Hours {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		seen := map[time.Weekday]string{}
+		for _, name := range names {
+			hours := schedule.Hours[name]
+			weekday, ok := parseWeekday(name)
+			if !ok {
+				return fmt.Errorf("invalid target hours schedule %d: unknown weekday %q", i+1, name)
+			}
+			if other, ok := seen[weekday]; ok {
+				return fmt.Errorf("invalid target hours schedule %d: weekday %q is also given as %q", i+1, name, other)
+			}
+			seen[weekday] = name
+			if hours < 0 || hours > 24 {
+				return fmt.Errorf("invalid target hours schedule %d: %.2fh for %s must be between 0 and 24", i+1, hours, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// TargetHours returns the contracted hours for the given date.
+// If several schedules are valid for the date, the one starting last wins.
+func (s Schedules) TargetHours(date time.Time) float64 {
+	var (
+		found     bool
+		foundFrom time.Time
+		target    float64
+	)
+
+	day := date.Format(dateFormat)
+	for _, schedule := range s {
+		if (schedule.ValidFrom != "" && day < schedule.ValidFrom) || (schedule.ValidUntil != "" && day > schedule.ValidUntil) {
+			continue
+		}
+
+		from, _, _ := schedule.period()
+		if found && from.Before(foundFrom) {
+			continue
+		}
+
+		found = true
+		foundFrom = from
+		target = schedule.hoursFor(date.Weekday())
+	}
+
+	return target
+}
+
+func (s Schedule) hoursFor(weekday time.Weekday) float64 {
+	for name, hours := range s.Hours {
+		if w, ok := parseWeekday(name); ok && w == weekday {
+			return hours
+		}
+	}
+
+	return 0
+}
+
+func (s Schedule) period() (time.Time, time.Time, error) {
+	var from, until time.Time
+	var err error
+
+	if s.ValidFrom != "" {
+		if from, err = time.Parse(dateFormat, s.ValidFrom); err != nil {
+			return from, until, fmt.Errorf("invalid valid-from %q: must be YYYY-MM-DD", s.ValidFrom)
+		}
+	}
+	if s.ValidUntil != "" {
+		if until, err = time.Parse(dateFormat, s.ValidUntil); err != nil {
+			return from, until, fmt.Errorf("invalid valid-until %q: must be YYYY-MM-DD", s.ValidUntil)
+		}
+	}
+
+	return from, until, nil
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedules_TargetHours(t *testing.T) {
+	schedules := Schedules{
+		{
+			ValidUntil: "2024-03-31",
+			Hours:      map[string]float64{"monday": 8, "tuesday": 8, "wednesday": 8, "thursday": 8, "friday": 8},
+		},
+		{
+			ValidFrom: "2024-04-01",
+			Hours:     map[string]float64{"Mon": 6, "Tue": 6, "Wed": 6, "Thu": 6},
+		},
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want float64
+	}{
+		{name: "full-time monday", date: time.Date(2024, time.March, 25, 0, 0, 0, 0, time.UTC), want: 8},
+		{name: "full-time saturday", date: time.Date(2024, time.March, 30, 0, 0, 0, 0, time.UTC), want: 0},
+		{name: "part-time monday", date: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), want: 6},
+		{name: "part-time friday", date: time.Date(2024, time.April, 5, 0, 0, 0, 0, time.UTC), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, schedules.TargetHours(tt.date))
+		})
+	}
+}
+
+func TestSchedules_TargetHours_laterScheduleWinsOnOverlap(t *testing.T) {
+	schedules := Schedules{
+		{Hours: map[string]float64{"mon": 8}},
+		{ValidFrom: "2024-06-01", Hours: map[string]float64{"mon": 4}},
+	}
+
+	assert.Equal(t, 8.0, schedules.TargetHours(time.Date(2024, time.May, 27, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 4.0, schedules.TargetHours(time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedules_Validate(t *testing.T) {
+	assert.NoError(t, Schedules{{ValidFrom: "2024-01-01", Hours: map[string]float64{"monday": 8}}}.Validate())
+
+	assert.EqualError(t,
+		Schedules{{ValidFrom: "01.01.2024"}}.Validate(),
+		`invalid target hours schedule 1: invalid valid-from "01.01.2024": must be YYYY-MM-DD`)
+	assert.EqualError(t,
+		Schedules{{Hours: map[string]float64{"funday": 8}}}.Validate(),
+		`invalid target hours schedule 1: unknown weekday "funday"`)
+	assert.EqualError(t,
+		Schedules{{Hours: map[string]float64{"mon": 25}}}.Validate(),
+		"invalid target hours schedule 1: 25.00h for mon must be between 0 and 24")
+	assert.EqualError(t,
+		Schedules{{Hours: map[string]float64{"monday": 8, "mon": 6}}}.Validate(),
+		`invalid target hours schedule 1: weekday "monday" is also given as "mon"`)
+	assert.EqualError(t,
+		Schedules{{ValidFrom: "2024-02-01", ValidUntil: "2024-01-01"}}.Validate(),
+		"invalid target hours schedule 1: valid-until 2024-01-01 is before valid-from 2024-02-01")
+}
+
+func TestReporter_Generate_summaryWithTargetHours(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{data: makeWeek5Entries()},
+		Schedules:            Schedules{{Hours: map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 8}}},
+	}
+
+	_, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "")
+	assert.Nil(t, err)
+
+	assert.True(t, summary.HasTarget)
+	assert.Len(t, summary.Days, 7)
+	assert.Equal(t, 3.0, summary.Total)
+	assert.Equal(t, 40.0, summary.Target)
+	assert.Equal(t, -37.0, summary.Delta())
+	assert.Equal(t, 1.0, summary.Days[0].Hours)
+	assert.Equal(t, -7.0, summary.Days[0].Delta())
+}
+
+func TestReporter_Generate_summarySkipsDaysOutsideMonthBoundary(t *testing.T) {
+	reporter := Reporter{
+		DescriptionDelimiter: "#",
+		Repository:           repositoryMock{data: makeWeek5Entries()},
+		Schedules:            Schedules{{Hours: map[string]float64{"mon": 8, "tue": 8, "wed": 8, "thu": 8, "fri": 8}}},
+	}
+
+	_, summary, err := generateTSV(reporter, 2022, 5, "ID", false, "end")
+	assert.Nil(t, err)
+
+	assert.Len(t, summary.Days, 1, "only Monday Jan 31 belongs to January")
+	assert.Equal(t, 8.0, summary.Target)
+	assert.Equal(t, -7.0, summary.Delta())
+}